@@ -0,0 +1,152 @@
+// Command apikey управляет API-ключами библиотеки: создаёт, перечисляет,
+// ротирует и отзывает их напрямую в базе. Первый ключ с правом admin
+// создаётся этой командой, остальными можно управлять через /admin/keys.
+//
+//	apikey create -name ci -owner alice -role editor -expires 720h
+//	apikey list
+//	apikey rotate -id 3
+//	apikey revoke -id 3
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/repository"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := run(os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "apikey:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: apikey create|list|rotate|revoke [flags]")
+}
+
+func run(command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	name := flags.String("name", "", "key name (create)")
+	owner := flags.String("owner", "", "key owner (create)")
+	role := flags.String("role", "viewer", "key role: viewer, contributor, editor, admin (create)")
+	expires := flags.Duration("expires", 0, "key lifetime, e.g. 720h; 0 never expires (create)")
+	id := flags.Uint("id", 0, "key ID (rotate, revoke)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	keys, err := newAPIKeyService()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch command {
+	case "create":
+		if *name == "" || *owner == "" {
+			return fmt.Errorf("create requires -name and -owner")
+		}
+		req := service.NewAPIKey{Name: *name, Owner: *owner, Role: *role}
+		if *expires > 0 {
+			expiresAt := time.Now().Add(*expires)
+			req.ExpiresAt = &expiresAt
+		}
+		key, secret, err := keys.CreateKey(ctx, req)
+		if err != nil {
+			return err
+		}
+		printSecret(os.Stdout, key, secret)
+	case "list":
+		all, err := keys.GetKeys(ctx)
+		if err != nil {
+			return err
+		}
+		printKeys(os.Stdout, all)
+	case "rotate":
+		key, secret, err := keys.RotateKey(ctx, *id)
+		if err != nil {
+			return err
+		}
+		printSecret(os.Stdout, key, secret)
+	case "revoke":
+		key, err := keys.RevokeKey(ctx, *id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("API key %d (%s) revoked\n", key.ID, key.Name)
+	default:
+		usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+	return nil
+}
+
+// newAPIKeyService подключается к базе из configs/config.json
+func newAPIKeyService() (*service.APIKeyService, error) {
+	cfg, err := config.New()
+	if err != nil {
+		return nil, err
+	}
+
+	dsn := fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+		cfg.DB.Host, cfg.DB.User, cfg.DB.Pass, cfg.DB.Name, cfg.DB.Port,
+	)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return service.NewAPIKeyService(repository.NewAPIKeyRepository(db, log), cfg, log), nil
+}
+
+func printSecret(w io.Writer, key *entity.APIKey, secret string) {
+	fmt.Fprintf(w, "API key %d (%s, owner %s, role %s)\n", key.ID, key.Name, key.Owner, key.Role)
+	fmt.Fprintln(w, secret)
+	fmt.Fprintln(w, "Store the key now: it cannot be shown again.")
+}
+
+func printKeys(w io.Writer, keys []entity.APIKey) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tOWNER\tPREFIX\tROLE\tSTATUS\tLAST USED")
+	now := time.Now()
+	for _, key := range keys {
+		status := "active"
+		switch {
+		case key.RevokedAt != nil:
+			status = "revoked"
+		case !key.Active(now):
+			status = "expired"
+		}
+		lastUsed := "never"
+		if key.LastUsedAt != nil {
+			lastUsed = key.LastUsedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.ID, key.Name, key.Owner, key.Prefix, key.Role, status, lastUsed)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture описывает ответ мок-сервера для пары group/song.
+// Ненулевой Status заставляет сервер вернуть эту ошибку вместо данных.
+type Fixture struct {
+	Group       string `json:"group" yaml:"group"`
+	Song        string `json:"song" yaml:"song"`
+	ReleaseDate string `json:"releaseDate" yaml:"releaseDate"`
+	Text        string `json:"text" yaml:"text"`
+	Link        string `json:"link" yaml:"link"`
+	Status      int    `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+func fixtureKey(group, song string) string {
+	return strings.ToLower(strings.TrimSpace(group)) + "\x00" + strings.ToLower(strings.TrimSpace(song))
+}
+
+// loadFixtures читает все *.json, *.yaml и *.yml файлы каталога.
+// Каждый файл содержит одну фикстуру или их список.
+func loadFixtures(dir string) (map[string]Fixture, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures directory: %w", err)
+	}
+
+	fixtures := make(map[string]Fixture)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		var list []Fixture
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			list, err = decodeFixtures(path, json.Unmarshal)
+		case ".yaml", ".yml":
+			list, err = decodeFixtures(path, yaml.Unmarshal)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range list {
+			if f.Group == "" || f.Song == "" {
+				return nil, fmt.Errorf("%s: fixture must have group and song", path)
+			}
+			fixtures[fixtureKey(f.Group, f.Song)] = f
+		}
+	}
+
+	return fixtures, nil
+}
+
+func decodeFixtures(path string, unmarshal func([]byte, any) error) ([]Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture file: %w", err)
+	}
+
+	var list []Fixture
+	if err := unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+
+	var single Fixture
+	if err := unmarshal(data, &single); err != nil {
+		return nil, fmt.Errorf("%s: failed to decode fixtures: %w", path, err)
+	}
+	return []Fixture{single}, nil
+}
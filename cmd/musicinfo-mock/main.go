@@ -0,0 +1,47 @@
+// Command musicinfo-mock запускает мок Music Info API (GET /info)
+// с ответами из каталога фикстур для локальной разработки и тестов.
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/musicinfo"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	addr := flag.String("addr", ":63342", "listen address")
+	dir := flag.String("fixtures", "cmd/musicinfo-mock/fixtures", "fixtures directory (JSON/YAML)")
+
+	var opts Options
+	flag.DurationVar(&opts.Latency, "latency", 0, "base response latency")
+	flag.DurationVar(&opts.Jitter, "jitter", 0, "random extra latency up to this value")
+	flag.Float64Var(&opts.ErrorRate, "error-rate", 0, "fraction of requests answered with 500")
+	flag.Float64Var(&opts.BadRequestRate, "bad-request-rate", 0, "fraction of requests answered with 400")
+	flag.IntVar(&opts.MissingStatus, "missing-status", http.StatusNotFound, "status for songs absent from fixtures")
+	flag.Parse()
+
+	log := logrus.New()
+
+	fixtures, err := loadFixtures(*dir)
+	if err != nil {
+		log.Fatal("Failed to load fixtures: ", err)
+	}
+
+	e := echo.New()
+	e.HideBanner = true
+	e.Use(middleware.Recover())
+	musicinfo.RegisterHandlers(e, newMockServer(fixtures, opts))
+
+	log.WithFields(logrus.Fields{
+		"addr":     *addr,
+		"fixtures": len(fixtures),
+	}).Info("Music info mock is running")
+
+	if err := e.Start(*addr); err != nil && err != http.ErrServerClosed {
+		log.Fatal("Music info mock error: ", err)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/musicinfo"
+	"github.com/labstack/echo/v4"
+)
+
+// Options задаёт поведение мок-сервера
+type Options struct {
+	Latency        time.Duration
+	Jitter         time.Duration
+	ErrorRate      float64
+	BadRequestRate float64
+	MissingStatus  int
+}
+
+// mockServer реализует musicinfo.ServerInterface на основе фикстур
+type mockServer struct {
+	fixtures map[string]Fixture
+	opts     Options
+	// random возвращает число из [0, 1) для выбора ошибки
+	random func() float64
+}
+
+var _ musicinfo.ServerInterface = (*mockServer)(nil)
+
+func newMockServer(fixtures map[string]Fixture, opts Options) *mockServer {
+	return &mockServer{fixtures: fixtures, opts: opts, random: rand.Float64}
+}
+
+func (s *mockServer) GetSongInfo(ctx echo.Context, params musicinfo.GetSongInfoParams) error {
+	if err := s.delay(ctx); err != nil {
+		return err
+	}
+
+	// одно число на запрос: доли ошибок откладываются подряд и не
+	// пересекаются, поэтому каждая соблюдается точно
+	switch r := s.random(); {
+	case r < s.opts.BadRequestRate:
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad request")
+	case r < s.opts.BadRequestRate+s.opts.ErrorRate:
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	fixture, ok := s.fixtures[fixtureKey(params.Group, params.Song)]
+	if !ok {
+		return echo.NewHTTPError(s.opts.MissingStatus, "Song not found")
+	}
+	if fixture.Status != 0 {
+		return echo.NewHTTPError(fixture.Status, http.StatusText(fixture.Status))
+	}
+
+	return ctx.JSON(http.StatusOK, musicinfo.SongDetail{
+		ReleaseDate: fixture.ReleaseDate,
+		Text:        fixture.Text,
+		Link:        fixture.Link,
+	})
+}
+
+// delay имитирует задержку провайдера с учётом разброса
+func (s *mockServer) delay(ctx echo.Context) error {
+	latency := s.opts.Latency
+	if s.opts.Jitter > 0 {
+		latency += rand.N(s.opts.Jitter)
+	}
+	if latency <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(latency):
+		return nil
+	case <-ctx.Request().Context().Done():
+		return ctx.Request().Context().Err()
+	}
+}
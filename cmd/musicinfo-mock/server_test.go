@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/musicinfo"
+	"github.com/labstack/echo/v4"
+)
+
+func newTestMock(t *testing.T, opts Options, random float64) *httptest.Server {
+	t.Helper()
+	fixtures := map[string]Fixture{
+		fixtureKey("Muse", "Uprising"): {
+			Group:       "Muse",
+			Song:        "Uprising",
+			ReleaseDate: "07.09.2009",
+			Text:        "Paranoia is in bloom",
+			Link:        "https://www.youtube.com/watch?v=w8KQmps-Sog",
+		},
+		fixtureKey("Muse", "Unreleased"): {Group: "Muse", Song: "Unreleased", Status: http.StatusServiceUnavailable},
+	}
+	s := newMockServer(fixtures, opts)
+	s.random = func() float64 { return random }
+
+	e := echo.New()
+	e.Logger.SetOutput(io.Discard)
+	musicinfo.RegisterHandlers(e, s)
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getInfo(t *testing.T, srv *httptest.Server, group, song string) *http.Response {
+	t.Helper()
+	query := url.Values{"group": {group}, "song": {song}}
+	resp, err := http.Get(srv.URL + "/info?" + query.Encode())
+	if err != nil {
+		t.Fatalf("GET /info: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestMockServerFixtures(t *testing.T) {
+	srv := newTestMock(t, Options{MissingStatus: http.StatusNotFound}, 0.5)
+
+	tests := []struct {
+		name        string
+		group, song string
+		status      int
+	}{
+		// поиск по фикстурам не зависит от регистра и пробелов
+		{"найдена", " muse ", "UPRISING", http.StatusOK},
+		{"нет в фикстурах", "Muse", "Hysteria", http.StatusNotFound},
+		{"ошибка из фикстуры", "Muse", "Unreleased", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		resp := getInfo(t, srv, tt.group, tt.song)
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, tt.status)
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			continue
+		}
+		var detail musicinfo.SongDetail
+		if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+			t.Errorf("%s: invalid body: %v", tt.name, err)
+			continue
+		}
+		if detail.ReleaseDate != "07.09.2009" || detail.Text != "Paranoia is in bloom" {
+			t.Errorf("%s: detail = %+v", tt.name, detail)
+		}
+	}
+}
+
+func TestMockServerMissingStatus(t *testing.T) {
+	srv := newTestMock(t, Options{MissingStatus: http.StatusNoContent}, 0.5)
+	if resp := getInfo(t, srv, "Muse", "Hysteria"); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+}
+
+func TestMockServerErrorRates(t *testing.T) {
+	opts := Options{BadRequestRate: 0.1, ErrorRate: 0.2, MissingStatus: http.StatusNotFound}
+	tests := []struct {
+		random float64
+		status int
+	}{
+		{0, http.StatusBadRequest},
+		{0.09, http.StatusBadRequest},
+		// доля 500 идёт сразу за долей 400, а не считается от остатка
+		{0.1, http.StatusInternalServerError},
+		{0.29, http.StatusInternalServerError},
+		{0.31, http.StatusOK},
+		{0.99, http.StatusOK},
+	}
+	for _, tt := range tests {
+		srv := newTestMock(t, opts, tt.random)
+		if resp := getInfo(t, srv, "Muse", "Uprising"); resp.StatusCode != tt.status {
+			t.Errorf("random %v: status = %d, want %d", tt.random, resp.StatusCode, tt.status)
+		}
+	}
+}
+
+func TestLoadFixtures(t *testing.T) {
+	fixtures, err := loadFixtures("fixtures")
+	if err != nil {
+		t.Fatalf("loadFixtures() error = %v", err)
+	}
+	if _, ok := fixtures[fixtureKey("Queen", "Bohemian Rhapsody")]; !ok {
+		t.Errorf("fixtures from queen.json are missing: %d loaded", len(fixtures))
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/graph"
 	"github.com/DusmatzodaQurbonli/song-library/internal/handler"
 	log "github.com/DusmatzodaQurbonli/song-library/internal/logger"
 	"github.com/DusmatzodaQurbonli/song-library/internal/repository"
 	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/DusmatzodaQurbonli/song-library/pkg/grpc"
 	"github.com/DusmatzodaQurbonli/song-library/pkg/http"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -26,13 +28,30 @@ func main() {
 			log.New,
 			initializeDB,
 			repository.NewSongRepository,
+			repository.NewSongChangeRepository,
+			repository.NewLyricRepository,
+			repository.NewAPIKeyRepository,
+			repository.NewRateLimitRepository,
 			service.NewMusicInfoClient, // Теперь передаем правильно
 			service.NewSongService,
+			service.NewResyncService,
+			service.NewLyricsService,
+			service.NewAPIKeyService,
+			service.NewAuthService,
+			service.NewRateLimitService,
 			handler.NewSongHandler,
+			handler.NewResyncHandler,
+			handler.NewLyricsHandler,
+			handler.NewMusicInfoHandler,
+			handler.NewAPIKeyHandler,
+			graph.NewHandler,
 			http.NewServer,
+			grpc.NewServer,
 		),
 		fx.Invoke(runMigrations),
 		fx.Invoke(startServer),
+		fx.Invoke(startGRPCServer),
+		fx.Invoke(startResync),
 	).Run()
 }
 
@@ -50,13 +69,7 @@ func initializeDB(cfg *config.Config, log *logrus.Logger) (*gorm.DB, error) {
 	return db, nil
 }
 
-func runMigrations(db *gorm.DB, log *logrus.Logger, cfg *config.Config) {
-	sqlDB, err := db.DB()
-	if err != nil {
-		log.Fatal("Failed to get database instance: ", err)
-	}
-	defer sqlDB.Close()
-
+func runMigrations(songs *repository.SongRepository, log *logrus.Logger, cfg *config.Config) {
 	m, err := migrate.New(
 		fmt.Sprintf("file://%s", "internal/migration/migrations"),
 		fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
@@ -76,6 +89,16 @@ func runMigrations(db *gorm.DB, log *logrus.Logger, cfg *config.Config) {
 	} else {
 		log.Info("Migrations applied successfully!")
 	}
+
+	// слаги песен, добавленных до их появления, строятся тем же кодом,
+	// что и для новых песен
+	n, err := songs.BackfillSlugs()
+	if err != nil {
+		log.Fatal("Failed to backfill song slugs: ", err)
+	}
+	if n > 0 {
+		log.Infof("Backfilled slugs for %d songs", n)
+	}
 }
 
 func startServer(lc fx.Lifecycle, srv *http.Server, cfg *config.Config, log *logrus.Logger) {
@@ -95,3 +118,35 @@ func startServer(lc fx.Lifecycle, srv *http.Server, cfg *config.Config, log *log
 		},
 	})
 }
+
+func startGRPCServer(lc fx.Lifecycle, srv *grpc.Server, cfg *config.Config, log *logrus.Logger) {
+	if !cfg.GRPC.Enabled {
+		return
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			log.Infof("Starting gRPC server on port %s", cfg.GRPC.Port)
+			return srv.Start()
+		},
+		OnStop: func(ctx context.Context) error {
+			log.Info("Shutting down gRPC server...")
+			srv.Stop()
+			return nil
+		},
+	})
+}
+
+func startResync(lc fx.Lifecycle, svc *service.ResyncService, log *logrus.Logger) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go svc.Run(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			log.Info("Stopping resync scheduler...")
+			cancel()
+			return nil
+		},
+	})
+}
@@ -0,0 +1,183 @@
+// Package apperror содержит доменные ошибки приложения и их представление
+// в виде application/problem+json (RFC 7807).
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Kind определяет категорию доменной ошибки
+type Kind string
+
+const (
+	KindNotFound     Kind = "not-found"
+	KindValidation   Kind = "validation"
+	KindConflict     Kind = "conflict"
+	KindUnavailable  Kind = "unavailable"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindRateLimited  Kind = "rate-limited"
+	KindInternal     Kind = "internal"
+)
+
+var kindStatuses = map[Kind]int{
+	KindNotFound:     http.StatusNotFound,
+	KindValidation:   http.StatusBadRequest,
+	KindConflict:     http.StatusConflict,
+	KindUnavailable:  http.StatusServiceUnavailable,
+	KindUnauthorized: http.StatusUnauthorized,
+	KindForbidden:    http.StatusForbidden,
+	KindRateLimited:  http.StatusTooManyRequests,
+	KindInternal:     http.StatusInternalServerError,
+}
+
+// Error — доменная ошибка с машиночитаемым кодом.
+// Status переопределяет статус ответа, заданный категорией,
+// RetryAfter передаётся клиенту в заголовке Retry-After.
+type Error struct {
+	Kind       Kind
+	Code       string
+	Detail     string
+	Status     int
+	RetryAfter time.Duration
+	Fields     []FieldError
+	Err        error
+}
+
+// FieldError описывает ошибку в одном поле запроса
+// @Description Invalid request field
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Detail + ": " + e.Err.Error()
+	}
+	return e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatus возвращает статус ответа для ошибки
+func (e *Error) HTTPStatus() int {
+	if e.Status != 0 {
+		return e.Status
+	}
+	if status, ok := kindStatuses[e.Kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithStatus переопределяет статус ответа
+func (e *Error) WithStatus(status int) *Error {
+	e.Status = status
+	return e
+}
+
+func NotFound(code, detail string, err error) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Detail: detail, Err: err}
+}
+
+func Validation(code, detail string, err error) *Error {
+	return &Error{Kind: KindValidation, Code: code, Detail: detail, Err: err}
+}
+
+// InvalidFields возвращает ошибку валидации со списком некорректных полей
+func InvalidFields(fields []FieldError, err error) *Error {
+	return &Error{
+		Kind:   KindValidation,
+		Code:   "invalid_fields",
+		Detail: "Request contains invalid fields",
+		Fields: fields,
+		Err:    err,
+	}
+}
+
+func Conflict(code, detail string, err error) *Error {
+	return &Error{Kind: KindConflict, Code: code, Detail: detail, Err: err}
+}
+
+func Unavailable(code, detail string, err error) *Error {
+	return &Error{Kind: KindUnavailable, Code: code, Detail: detail, Err: err}
+}
+
+// Unauthorized — запрос без действительных учётных данных
+func Unauthorized(code, detail string, err error) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Detail: detail, Err: err}
+}
+
+// Forbidden — учётных данных недостаточно для операции
+func Forbidden(code, detail string, err error) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Detail: detail, Err: err}
+}
+
+// Internal — сбой на стороне сервиса, не связанный с данными клиента
+func Internal(code, detail string, err error) *Error {
+	return &Error{Kind: KindInternal, Code: code, Detail: detail, Err: err}
+}
+
+// RateLimited — клиент исчерпал лимит запросов; повторить можно
+// через retryAfter
+func RateLimited(code, detail string, retryAfter time.Duration) *Error {
+	return &Error{Kind: KindRateLimited, Code: code, Detail: detail, RetryAfter: retryAfter}
+}
+
+// As извлекает доменную ошибку из цепочки err
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// IsKind сообщает, относится ли err к категории kind
+func IsKind(err error, kind Kind) bool {
+	appErr, ok := As(err)
+	return ok && appErr.Kind == kind
+}
+
+// Problem — тело ответа application/problem+json
+// @Description RFC 7807 problem details
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// ContentType — тип содержимого ответа с ошибкой
+const ContentType = "application/problem+json"
+
+// NewProblem строит описание проблемы для ошибки. Детали неизвестных
+// ошибок не раскрываются клиенту.
+func NewProblem(err error, instance, requestID string) Problem {
+	appErr, ok := As(err)
+	if !ok {
+		appErr = &Error{Kind: KindInternal, Code: "internal_error", Detail: "Internal Server Error"}
+	}
+
+	status := appErr.HTTPStatus()
+	return Problem{
+		Type:      "/problems/" + strings.ReplaceAll(appErr.Code, "_", "-"),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    appErr.Detail,
+		Instance:  instance,
+		Code:      appErr.Code,
+		RequestID: requestID,
+		Errors:    appErr.Fields,
+	}
+}
@@ -0,0 +1,146 @@
+// Package auth описывает субъект запроса и права доступа к API.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+)
+
+// Permission — право на группу операций с библиотекой
+type Permission string
+
+const (
+	// PermRead разрешает чтение песен и текстов
+	PermRead Permission = "read"
+	// PermCreate разрешает добавление песен
+	PermCreate Permission = "create"
+	// PermUpdate разрешает изменение песен, их полей и разметки текста
+	PermUpdate Permission = "update"
+	// PermDelete разрешает удаление песен
+	PermDelete Permission = "delete"
+	// PermRestore разрешает восстановление удалённых данных
+	PermRestore Permission = "restore"
+	// PermImport разрешает загрузку данных провайдера и разбор его изменений
+	PermImport Permission = "import"
+	// PermAdmin разрешает управление ключами доступа
+	PermAdmin Permission = "admin"
+)
+
+// Role — роль субъекта, определяющая набор его прав
+type Role string
+
+const (
+	RoleViewer      Role = "viewer"
+	RoleContributor Role = "contributor"
+	RoleEditor      Role = "editor"
+	RoleAdmin       Role = "admin"
+)
+
+// Roles перечисляет роли по возрастанию прав
+var Roles = []Role{RoleViewer, RoleContributor, RoleEditor, RoleAdmin}
+
+// rolePermissions — матрица прав ролей
+var rolePermissions = map[Role][]Permission{
+	RoleViewer:      {PermRead},
+	RoleContributor: {PermRead, PermCreate, PermUpdate},
+	RoleEditor:      {PermRead, PermCreate, PermUpdate, PermDelete, PermRestore, PermImport},
+	RoleAdmin:       {PermRead, PermCreate, PermUpdate, PermDelete, PermRestore, PermImport, PermAdmin},
+}
+
+// IsRole сообщает, известна ли роль
+func IsRole(s string) bool {
+	_, ok := rolePermissions[Role(s)]
+	return ok
+}
+
+// Allows сообщает, есть ли у роли право
+func (r Role) Allows(perm Permission) bool {
+	return slices.Contains(rolePermissions[r], perm)
+}
+
+// Principal — субъект запроса: владелец API-ключа, пользователь
+// провайдера идентификации или анонимный клиент. Анонимный клиент без
+// роли не может ничего. Subject и Claims заполняются для JWT и нужны
+// для аудита изменений.
+type Principal struct {
+	KeyID     uint
+	Name      string
+	Owner     string
+	Subject   string
+	Claims    map[string]any
+	Role      Role
+	Anonymous bool
+}
+
+// Has сообщает, есть ли у субъекта право
+func (p *Principal) Has(perm Permission) bool {
+	return p.Role.Allows(perm)
+}
+
+type principalKey struct{}
+
+// WithPrincipal сохраняет субъект запроса в контексте
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext возвращает субъект запроса из контекста
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// Require проверяет, что у субъекта запроса есть право perm.
+// Анонимный клиент без права получает 401, остальные — 403.
+func Require(ctx context.Context, perm Permission) error {
+	p, ok := FromContext(ctx)
+	if !ok || (p.Anonymous && !p.Has(perm)) {
+		return apperror.Unauthorized("unauthenticated", "A valid API key or bearer token is required", nil)
+	}
+	if !p.Has(perm) {
+		return apperror.Forbidden("permission_denied",
+			fmt.Sprintf("Role %q does not have the %s permission", p.Role, perm), nil)
+	}
+	return nil
+}
+
+// keyPrefix отличает API-ключи библиотеки от других секретов
+const keyPrefix = "slk_"
+
+// displayLength — длина начала ключа, по которому его узнают в списках
+const displayLength = len(keyPrefix) + 8
+
+// GenerateKey создаёт новый API-ключ и возвращает его вместе с началом
+// ключа для отображения и хешем для хранения
+func GenerateKey() (key, display, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", fmt.Errorf("generate API key: %w", err)
+	}
+	key = keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return key, key[:displayLength], HashKey(key), nil
+}
+
+// HashKey возвращает хеш API-ключа. Ключи случайны и длинны,
+// поэтому медленная функция хеширования не нужна.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// KeyFromHeaders извлекает API-ключ или JWT из заголовка
+// Authorization: Bearer или API-ключ из X-API-Key
+func KeyFromHeaders(authorization, apiKey string) string {
+	if scheme, token, ok := strings.Cut(authorization, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return strings.TrimSpace(apiKey)
+}
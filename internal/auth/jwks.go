@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// minRefetchInterval ограничивает перечитывание JWKS при неизвестном kid,
+// чтобы токены с чужими kid не превращались в поток запросов к провайдеру
+const minRefetchInterval = 30 * time.Second
+
+// maxJWKSSize ограничивает размер загружаемого набора ключей
+const maxJWKSSize = 1 << 20
+
+// JWKS — кеш открытых ключей подписи из JSON Web Key Set. Набор читается
+// по URL или из файла, перечитывается по истечении refresh и при
+// появлении неизвестного kid, что позволяет провайдеру ротировать ключи.
+type JWKS struct {
+	url     string
+	file    string
+	refresh time.Duration
+	client  *http.Client
+
+	// mu защищает набор ключей и не удерживается во время загрузки;
+	// fetchMu не даёт загружать набор нескольким запросам сразу
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+
+	fetchMu     sync.Mutex
+	attemptedAt time.Time
+}
+
+// NewJWKS создаёт кеш ключей; url имеет приоритет над file
+func NewJWKS(url, file string, refresh time.Duration) *JWKS {
+	return &JWKS{
+		url:     url,
+		file:    file,
+		refresh: refresh,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Key возвращает ключ по kid. Токен без kid принимается, только если
+// в наборе ровно один ключ.
+func (j *JWKS) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	j.mu.RLock()
+	key, found := j.lookup(kid)
+	stale := j.keys == nil || (j.refresh > 0 && time.Since(j.fetchedAt) > j.refresh)
+	j.mu.RUnlock()
+
+	if stale || !found {
+		if err := j.reload(ctx, stale, !found); err != nil && j.empty() {
+			return nil, err
+		}
+		j.mu.RLock()
+		key, found = j.lookup(kid)
+		j.mu.RUnlock()
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func (j *JWKS) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(j.keys) == 1 {
+		for _, key := range j.keys {
+			return key, true
+		}
+	}
+	key, ok := j.keys[kid]
+	return key, ok
+}
+
+func (j *JWKS) empty() bool {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return len(j.keys) == 0
+}
+
+// reload перечитывает набор ключей не чаще minRefetchInterval; force
+// означает плановое обновление устаревшего набора. Запрос, которому ключ
+// нужен (wait), дожидается загрузки, начатой другим запросом, остальные
+// продолжают с прежним набором. При ошибке прежний набор остаётся в силе.
+func (j *JWKS) reload(ctx context.Context, force, wait bool) error {
+	if wait {
+		j.fetchMu.Lock()
+	} else if !j.fetchMu.TryLock() {
+		return nil
+	}
+	defer j.fetchMu.Unlock()
+
+	j.mu.RLock()
+	fresh := j.keys != nil && time.Since(j.fetchedAt) <= j.refresh
+	j.mu.RUnlock()
+	if force && fresh {
+		// набор уже обновил другой запрос
+		return nil
+	}
+	if time.Since(j.attemptedAt) < minRefetchInterval {
+		return errors.New("signing keys are unavailable, retrying later")
+	}
+	j.attemptedAt = time.Now()
+
+	data, err := j.read(ctx)
+	if err != nil {
+		return fmt.Errorf("load JWKS: %w", err)
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("parse JWKS: %w", err)
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.fetchedAt = time.Now()
+	j.mu.Unlock()
+	return nil
+}
+
+func (j *JWKS) read(ctx context.Context) ([]byte, error) {
+	if j.url == "" {
+		return os.ReadFile(j.file)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+}
+
+// jwk — открытый ключ в формате RFC 7517
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWKS разбирает набор ключей. Поддерживаются ключи RSA и EC
+// (P-256, P-384, P-521); ключи шифрования и неизвестных типов пропускаются.
+func ParseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsa()
+		case "EC":
+			key, err = k.ecdsa()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) rsa() (*rsa.PublicKey, error) {
+	n, err := decodeInt(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("modulus: %w", err)
+	}
+	e, err := decodeInt(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("exponent: %w", err)
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k jwk) ecdsa() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+	x, err := decodeInt(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("x: %w", err)
+	}
+	y, err := decodeInt(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("y: %w", err)
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("point is not on the curve")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) jwk {
+	return jwk{Kty: "RSA", Kid: kid, Use: "sig", N: b64(key.N.Bytes()), E: b64(big.NewInt(int64(key.E)).Bytes())}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) jwk {
+	return jwk{Kty: "EC", Kid: kid, Crv: key.Curve.Params().Name, X: b64(key.X.Bytes()), Y: b64(key.Y.Bytes())}
+}
+
+func jwksJSON(t *testing.T, keys ...jwk) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string][]jwk{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	offCurve := ecJWK("ec", &ecKey.PublicKey)
+	offCurve.Y = b64(new(big.Int).Add(ecKey.Y, big.NewInt(1)).Bytes())
+	badCurve := ecJWK("ec", &ecKey.PublicKey)
+	badCurve.Crv = "secp256k1"
+	smallExponent := rsaJWK("rsa", &rsaKey.PublicKey)
+	smallExponent.E = b64([]byte{1})
+	emptyModulus := rsaJWK("rsa", &rsaKey.PublicKey)
+	emptyModulus.N = ""
+	badBase64 := rsaJWK("rsa", &rsaKey.PublicKey)
+	badBase64.N = "not base64!"
+	encryption := rsaJWK("enc", &rsaKey.PublicKey)
+	encryption.Use = "enc"
+
+	tests := []struct {
+		name    string
+		data    []byte
+		kids    []string
+		wantErr string
+	}{
+		{"RSA и EC", jwksJSON(t, rsaJWK("rsa", &rsaKey.PublicKey), ecJWK("ec", &ecKey.PublicKey)), []string{"ec", "rsa"}, ""},
+		{"ключи шифрования и неизвестные типы пропускаются",
+			jwksJSON(t, encryption, jwk{Kty: "oct", Kid: "hmac"}, rsaJWK("rsa", &rsaKey.PublicKey)), []string{"rsa"}, ""},
+		{"точка не на кривой", jwksJSON(t, offCurve), nil, "not on the curve"},
+		{"неизвестная кривая", jwksJSON(t, badCurve), nil, "unsupported curve"},
+		{"малая экспонента", jwksJSON(t, smallExponent), nil, "invalid exponent"},
+		{"пустой модуль", jwksJSON(t, emptyModulus), nil, "empty value"},
+		{"модуль не base64url", jwksJSON(t, badBase64), nil, "modulus"},
+		{"нет ключей подписи", jwksJSON(t, encryption), nil, "no signing keys"},
+		{"не JSON", []byte("{"), nil, "unexpected end"},
+	}
+	for _, tt := range tests {
+		keys, err := ParseJWKS(tt.data)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: ParseJWKS() error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ParseJWKS() error = %v", tt.name, err)
+			continue
+		}
+		if len(keys) != len(tt.kids) {
+			t.Errorf("%s: ParseJWKS() returned %d keys, want %d", tt.name, len(keys), len(tt.kids))
+		}
+		for _, kid := range tt.kids {
+			if _, ok := keys[kid]; !ok {
+				t.Errorf("%s: key %q is missing", tt.name, kid)
+			}
+		}
+	}
+}
+
+func TestJWKSKeyRotation(t *testing.T) {
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	newKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	write := func(keys ...jwk) {
+		if err := os.WriteFile(file, jwksJSON(t, keys...), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(ecJWK("old", &oldKey.PublicKey))
+	j := NewJWKS("", file, time.Hour)
+	ctx := context.Background()
+	if _, err := j.Key(ctx, "old"); err != nil {
+		t.Fatalf("Key(old) error = %v", err)
+	}
+
+	// провайдер сменил ключ, но повторная загрузка ещё не разрешена
+	write(ecJWK("new", &newKey.PublicKey))
+	if _, err := j.Key(ctx, "new"); err == nil {
+		t.Errorf("Key(new) within minRefetchInterval succeeded, want error")
+	}
+
+	j.attemptedAt = time.Now().Add(-minRefetchInterval)
+	tests := []struct {
+		kid     string
+		wantErr bool
+	}{
+		{"new", false},
+		{"old", true},
+		{"", false},
+	}
+	for _, tt := range tests {
+		key, err := j.Key(ctx, tt.kid)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Key(%q) error = %v, wantErr %v", tt.kid, err, tt.wantErr)
+		}
+		if err == nil && !newKey.PublicKey.Equal(key) {
+			t.Errorf("Key(%q) returned a key other than the rotated one", tt.kid)
+		}
+	}
+}
+
+func TestJWKSKeepsKeysOnFailedReload(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(file, jwksJSON(t, ecJWK("k", &key.PublicKey)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	j := NewJWKS("", file, time.Hour)
+	ctx := context.Background()
+	if _, err := j.Key(ctx, "k"); err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+
+	// набор устарел, а новый прочитать не удалось: прежние ключи остаются
+	if err := os.WriteFile(file, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	j.fetchedAt = time.Now().Add(-2 * time.Hour)
+	j.attemptedAt = time.Time{}
+	if _, err := j.Key(ctx, "k"); err != nil {
+		t.Errorf("Key() after failed reload error = %v", err)
+	}
+}
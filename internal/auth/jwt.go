@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenOptions — требования к JWT провайдера идентификации. DefaultRole
+// получают токены без известной роли; пустая роль означает отказ.
+type TokenOptions struct {
+	Issuer      string
+	Audience    string
+	ClockSkew   time.Duration
+	RoleClaim   string
+	DefaultRole Role
+}
+
+// TokenVerifier проверяет подпись JWT (RS256, ES256) по ключам из JWKS,
+// а также издателя, получателя и срок действия токена
+type TokenVerifier struct {
+	keys        *JWKS
+	parser      *jwt.Parser
+	roleClaim   string
+	defaultRole Role
+}
+
+// NewTokenVerifier создаёт проверку токенов с ключами из keys
+func NewTokenVerifier(keys *JWKS, opts TokenOptions) *TokenVerifier {
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithLeeway(opts.ClockSkew),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
+	roleClaim := opts.RoleClaim
+	if roleClaim == "" {
+		roleClaim = "roles"
+	}
+	return &TokenVerifier{
+		keys:        keys,
+		parser:      jwt.NewParser(parserOpts...),
+		roleClaim:   roleClaim,
+		defaultRole: opts.DefaultRole,
+	}
+}
+
+// IsJWT сообщает, похож ли предъявленный секрет на JWT: API-ключи
+// библиотеки не содержат точек
+func IsJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// Verify проверяет токен и возвращает субъект запроса с его claims
+func (v *TokenVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, apperror.Unauthorized("invalid_token", "Bearer token is not valid", err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, apperror.Unauthorized("invalid_token", "Bearer token has no subject", err)
+	}
+
+	role, ok := tokenRole(claims[v.roleClaim])
+	if !ok {
+		if v.defaultRole == "" {
+			return nil, apperror.Forbidden("no_role", "Bearer token has no known role", nil)
+		}
+		role = v.defaultRole
+	}
+
+	name, _ := claims["preferred_username"].(string)
+	if name == "" {
+		name = subject
+	}
+	return &Principal{
+		Name:    name,
+		Subject: subject,
+		Role:    role,
+		Claims:  claims,
+	}, nil
+}
+
+// tokenRole выбирает старшую из известных ролей в claim: строке с ролями
+// через пробел или массиве строк; ok ложно, если известных ролей нет
+func tokenRole(claim any) (role Role, ok bool) {
+	var values []string
+	switch c := claim.(type) {
+	case string:
+		values = strings.Fields(c)
+	case []any:
+		for _, v := range c {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	for _, v := range values {
+		if IsRole(v) && (!ok || slices.Index(Roles, Role(v)) > slices.Index(Roles, role)) {
+			role, ok = Role(v), true
+		}
+	}
+	return role, ok
+}
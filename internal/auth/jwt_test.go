@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://id.example.com"
+	testAudience = "song-library"
+)
+
+// testKeys — ключи подписи и JWKS с их открытыми частями
+type testKeys struct {
+	rsa   *rsa.PrivateKey
+	ec    *ecdsa.PrivateKey
+	other *ecdsa.PrivateKey
+	jwks  *JWKS
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	data := jwksJSON(t, rsaJWK("rsa", &rsaKey.PublicKey), ecJWK("ec", &ecKey.PublicKey))
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return testKeys{rsa: rsaKey, ec: ecKey, other: otherKey, jwks: NewJWKS("", file, time.Hour)}
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"sub":   "user-1",
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"roles": []string{"editor"},
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func with(claims jwt.MapClaims, key string, value any) jwt.MapClaims {
+	if value == nil {
+		delete(claims, key)
+	} else {
+		claims[key] = value
+	}
+	return claims
+}
+
+func TestVerify(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := NewTokenVerifier(keys.jwks, TokenOptions{
+		Issuer:    testIssuer,
+		Audience:  testAudience,
+		ClockSkew: 30 * time.Second,
+	})
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		token    string
+		wantKind apperror.Kind
+	}{
+		{"RS256", sign(t, jwt.SigningMethodRS256, "rsa", keys.rsa, validClaims()), ""},
+		{"ES256", sign(t, jwt.SigningMethodES256, "ec", keys.ec, validClaims()), ""},
+		{"истёк в пределах допуска",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "exp", now.Add(-10*time.Second).Unix())), ""},
+		{"истёк за пределами допуска",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "exp", now.Add(-time.Minute).Unix())), apperror.KindUnauthorized},
+		{"без exp",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "exp", nil)), apperror.KindUnauthorized},
+		{"nbf в будущем",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "nbf", now.Add(time.Minute).Unix())), apperror.KindUnauthorized},
+		{"iat в будущем",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "iat", now.Add(time.Minute).Unix())), apperror.KindUnauthorized},
+		{"чужой издатель",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "iss", "https://evil.example.com")), apperror.KindUnauthorized},
+		{"чужой получатель",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "aud", "other-service")), apperror.KindUnauthorized},
+		{"без субъекта",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "sub", nil)), apperror.KindUnauthorized},
+		{"alg=none",
+			sign(t, jwt.SigningMethodNone, "ec", jwt.UnsafeAllowNoneSignatureType, validClaims()), apperror.KindUnauthorized},
+		{"HS256 с открытым ключом как секретом",
+			sign(t, jwt.SigningMethodHS256, "rsa", keys.rsa.PublicKey.N.Bytes(), validClaims()), apperror.KindUnauthorized},
+		{"RS384 не разрешён",
+			sign(t, jwt.SigningMethodRS384, "rsa", keys.rsa, validClaims()), apperror.KindUnauthorized},
+		{"kid от ключа другого типа",
+			sign(t, jwt.SigningMethodES256, "rsa", keys.ec, validClaims()), apperror.KindUnauthorized},
+		{"подпись чужим ключом",
+			sign(t, jwt.SigningMethodES256, "ec", keys.other, validClaims()), apperror.KindUnauthorized},
+		{"неизвестный kid",
+			sign(t, jwt.SigningMethodES256, "unknown", keys.ec, validClaims()), apperror.KindUnauthorized},
+		{"без kid при нескольких ключах",
+			sign(t, jwt.SigningMethodES256, "", keys.ec, validClaims()), apperror.KindUnauthorized},
+		{"без известной роли",
+			sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "roles", []string{"superuser"})), apperror.KindForbidden},
+		{"не JWT", "a.b.c", apperror.KindUnauthorized},
+	}
+	for _, tt := range tests {
+		p, err := verifier.Verify(context.Background(), tt.token)
+		if tt.wantKind == "" {
+			if err != nil {
+				t.Errorf("%s: Verify() error = %v", tt.name, err)
+			} else if p.Subject != "user-1" || p.Role != RoleEditor {
+				t.Errorf("%s: Verify() = {Subject: %q, Role: %q}, want {user-1, editor}", tt.name, p.Subject, p.Role)
+			}
+			continue
+		}
+		if !apperror.IsKind(err, tt.wantKind) {
+			t.Errorf("%s: Verify() error = %v, want kind %s", tt.name, err, tt.wantKind)
+		}
+	}
+}
+
+func TestVerifyDefaultRole(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := NewTokenVerifier(keys.jwks, TokenOptions{DefaultRole: RoleViewer})
+
+	tests := []struct {
+		roles any
+		want  Role
+	}{
+		{nil, RoleViewer},
+		{[]string{"superuser"}, RoleViewer},
+		{"contributor", RoleContributor},
+	}
+	for _, tt := range tests {
+		token := sign(t, jwt.SigningMethodES256, "ec", keys.ec, with(validClaims(), "roles", tt.roles))
+		p, err := verifier.Verify(context.Background(), token)
+		if err != nil {
+			t.Errorf("Verify(roles=%v) error = %v", tt.roles, err)
+			continue
+		}
+		if p.Role != tt.want {
+			t.Errorf("Verify(roles=%v) role = %q, want %q", tt.roles, p.Role, tt.want)
+		}
+	}
+}
+
+func TestTokenRole(t *testing.T) {
+	tests := []struct {
+		claim any
+		want  Role
+		ok    bool
+	}{
+		{"editor", RoleEditor, true},
+		{"viewer admin contributor", RoleAdmin, true},
+		{[]any{"contributor", "viewer"}, RoleContributor, true},
+		{[]any{"viewer", 42, "editor"}, RoleEditor, true},
+		{[]any{"owner"}, "", false},
+		{"", "", false},
+		{nil, "", false},
+		{42, "", false},
+	}
+	for _, tt := range tests {
+		role, ok := tokenRole(tt.claim)
+		if role != tt.want || ok != tt.ok {
+			t.Errorf("tokenRole(%v) = %q, %v, want %q, %v", tt.claim, role, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestIsJWT(t *testing.T) {
+	tests := []struct {
+		token string
+		want  bool
+	}{
+		{"header.payload.signature", true},
+		{"sl_0123456789abcdef", false},
+		{"a.b", false},
+		{"a.b.c.d", false},
+	}
+	for _, tt := range tests {
+		if got := IsJWT(tt.token); got != tt.want {
+			t.Errorf("IsJWT(%q) = %v, want %v", tt.token, got, tt.want)
+		}
+	}
+}
@@ -21,9 +21,146 @@ type LogLevel struct {
 	ERROR string `json:"error"`
 }
 
+// Server настраивает HTTP-сервер. TrustedProxies перечисляет адреса и сети
+// прокси, которым разрешено передавать адрес клиента в X-Forwarded-For;
+// по умолчанию не доверяется никому и клиентом считается адрес соединения.
 type Server struct {
-	Host string `json:"host"`
-	Port string `json:"port"`
+	Host           string   `json:"host"`
+	Port           string   `json:"port"`
+	TrustedProxies []string `json:"trusted_proxies"`
+}
+
+// MusicInfoProvider описывает один источник метаданных песен.
+// Провайдеры с меньшим Priority опрашиваются первыми. APIKey передаётся
+// провайдеру в заголовке X-API-Key, если он требует учётные данные
+// (например, другой экземпляр библиотеки с включённой авторизацией).
+type MusicInfoProvider struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Priority int    `json:"priority"`
+	APIKey   string `json:"api_key"`
+}
+
+// MusicInfo настраивает реестр провайдеров метаданных.
+// Mode: "sequential" (по умолчанию) или "parallel".
+// FieldPrecedence задаёт порядок провайдеров для отдельных полей
+// (release_date, text, link); для остальных полей используется Priority.
+// Timeout ограничивает каждый запрос к провайдеру, в том числе к
+// music_info_api, в формате time.ParseDuration (по умолчанию "10s").
+type MusicInfo struct {
+	Mode            string              `json:"mode"`
+	Providers       []MusicInfoProvider `json:"providers"`
+	FieldPrecedence map[string][]string `json:"field_precedence"`
+	Timeout         string              `json:"timeout"`
+}
+
+// Resync настраивает периодическое обновление метаданных песен.
+// Interval и MaxAge задаются в формате time.ParseDuration ("24h", "720h").
+// Policy: "auto" применяет изменения сразу, "review" ставит их в очередь.
+type Resync struct {
+	Enabled   bool   `json:"enabled"`
+	Interval  string `json:"interval"`
+	MaxAge    string `json:"max_age"`
+	Policy    string `json:"policy"`
+	BatchSize int    `json:"batch_size"`
+}
+
+// RouteDeprecation помечает маршрут устаревшим.
+// Path — шаблон маршрута gin с префиксом версии ("/api/v1/songs/:id"),
+// Since и Sunset задаются в формате RFC 3339, Link указывает на замену.
+type RouteDeprecation struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Since  string `json:"since"`
+	Sunset string `json:"sunset"`
+	Link   string `json:"link"`
+}
+
+// Cache задаёт Cache-Control для GET-маршрутов. Ключ Routes — метод
+// и шаблон маршрута ("GET /api/v1/songs/:id"), Default применяется к остальным.
+type Cache struct {
+	Default string            `json:"default"`
+	Routes  map[string]string `json:"routes"`
+}
+
+// API настраивает версии API. Маршруты без префикса /api/v1 остаются
+// псевдонимами v1, пока не выставлен DisableLegacyRoutes; LegacySince
+// и LegacySunset задают для них заголовки Deprecation и Sunset.
+// ServeMusicInfo открывает GET /info в формате Music Info API, чтобы
+// другие экземпляры могли использовать библиотеку как провайдера.
+type API struct {
+	DisableLegacyRoutes bool               `json:"disable_legacy_routes"`
+	LegacySince         string             `json:"legacy_since"`
+	LegacySunset        string             `json:"legacy_sunset"`
+	Deprecations        []RouteDeprecation `json:"deprecations"`
+	Cache               Cache              `json:"cache"`
+	ServeMusicInfo      bool               `json:"serve_music_info"`
+}
+
+// GraphQL настраивает эндпоинт /graphql. MaxComplexity ограничивает
+// стоимость запроса (поле стоит 1, списки умножают стоимость вложенных
+// полей на size), MaxDepth — вложенность. Playground включает GraphiQL.
+type GraphQL struct {
+	Enabled       bool `json:"enabled"`
+	MaxComplexity int  `json:"max_complexity"`
+	MaxDepth      int  `json:"max_depth"`
+	Playground    bool `json:"playground"`
+}
+
+// GRPC настраивает gRPC-сервер SongLibrary, который работает
+// параллельно с HTTP-сервером на отдельном порту
+type GRPC struct {
+	Enabled    bool   `json:"enabled"`
+	Host       string `json:"host"`
+	Port       string `json:"port"`
+	Reflection bool   `json:"reflection"`
+}
+
+// JWT настраивает проверку токенов провайдера идентификации (OIDC).
+// Ключи подписи читаются из JWKS по JWKSURL или из файла JWKSFile
+// и перечитываются раз в RefreshInterval, а также при неизвестном kid.
+// ClockSkew допускает расхождение часов при проверке exp, nbf и iat.
+// RoleClaim — claim с ролями: строка через пробел или массив.
+// DefaultRole получают токены без известной роли; если она не задана,
+// такие токены отклоняются.
+type JWT struct {
+	Enabled         bool   `json:"enabled"`
+	JWKSURL         string `json:"jwks_url"`
+	JWKSFile        string `json:"jwks_file"`
+	Issuer          string `json:"issuer"`
+	Audience        string `json:"audience"`
+	ClockSkew       string `json:"clock_skew"`
+	RefreshInterval string `json:"refresh_interval"`
+	RoleClaim       string `json:"role_claim"`
+	DefaultRole     string `json:"default_role"`
+}
+
+// Auth настраивает доступ к API по ключам и JWT. AnonymousRead разрешает
+// чтение без учётных данных; изменения и управление ключами всегда их требуют.
+// Выключенный Enabled открывает все операции, как до появления ключей.
+type Auth struct {
+	Enabled       bool `json:"enabled"`
+	AnonymousRead bool `json:"anonymous_read"`
+	JWT           JWT  `json:"jwt"`
+}
+
+// RateLimitBudget — бюджет запросов: Requests за Period (в формате
+// time.ParseDuration) с запасом Burst для всплесков, по умолчанию Requests
+type RateLimitBudget struct {
+	Requests int    `json:"requests"`
+	Period   string `json:"period"`
+	Burst    int    `json:"burst"`
+}
+
+// RateLimit настраивает ограничение частоты запросов по API-ключу или
+// адресу клиента с отдельными бюджетами чтения и записи.
+// Store: "memory" (по умолчанию, у каждой реплики свои лимиты)
+// или "postgres" (лимиты общие для всех реплик).
+type RateLimit struct {
+	Enabled bool            `json:"enabled"`
+	Store   string          `json:"store"`
+	Read    RateLimitBudget `json:"read"`
+	Write   RateLimitBudget `json:"write"`
 }
 
 type Config struct {
@@ -31,10 +168,26 @@ type Config struct {
 	LogLevel     LogLevel `json:"log_level"`
 	Server       Server   `json:"server"`
 	MusicInfoAPI string   `json:"music_info_api"`
+	// MusicInfoAPIKey — ключ для music_info_api, см. MusicInfoProvider.APIKey
+	MusicInfoAPIKey string    `json:"music_info_api_key"`
+	MusicInfo       MusicInfo `json:"music_info"`
+	Resync          Resync    `json:"resync"`
+	API             API       `json:"api"`
+	GraphQL         GraphQL   `json:"graphql"`
+	GRPC            GRPC      `json:"grpc"`
+	Auth            Auth      `json:"auth"`
+	RateLimit       RateLimit `json:"rate_limit"`
 }
 
+// defaultConfigPath — конфигурация по умолчанию. Путь к другой, например
+// configs/config.dev.json с GraphiQL и reflection, задаёт CONFIG_PATH.
+const defaultConfigPath = "configs/config.json"
+
 func New() (*Config, error) {
-	filePath := "configs/config.json"
+	filePath := os.Getenv("CONFIG_PATH")
+	if filePath == "" {
+		filePath = defaultConfigPath
+	}
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to open configuration file: %w", err)
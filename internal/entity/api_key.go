@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// APIKey — ключ доступа к API. Хранится только хеш ключа: сам ключ
+// показывается один раз при создании или ротации.
+// @Description API key
+type APIKey struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	Name       string     `gorm:"not null" json:"name"`
+	Owner      string     `gorm:"not null" json:"owner"`
+	Prefix     string     `gorm:"not null" json:"prefix"`
+	Hash       string     `gorm:"not null;uniqueIndex" json:"-"`
+	Role       string     `gorm:"not null" json:"role"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Active сообщает, можно ли пользоваться ключом в момент now
+func (k APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
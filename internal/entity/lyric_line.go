@@ -0,0 +1,17 @@
+package entity
+
+// LyricLine — строка текста песни с отметкой времени для караоке
+type LyricLine struct {
+	ID       uint        `gorm:"primaryKey"`
+	SongID   uint        `gorm:"not null;index"`
+	Position int         `gorm:"not null"`
+	StartMS  int64       `gorm:"column:start_ms;not null"`
+	Text     string      `gorm:"type:text;not null"`
+	Words    []LyricWord `gorm:"type:jsonb;serializer:json"`
+}
+
+// LyricWord — слово строки с отметкой времени (enhanced LRC)
+type LyricWord struct {
+	StartMS int64  `json:"start_ms"`
+	Text    string `json:"text"`
+}
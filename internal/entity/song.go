@@ -3,21 +3,70 @@ package entity
 import (
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Song представляет песню в библиотеке
 // @Description Song entity
 type Song struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Group       string    `gorm:"not null" json:"group"`
-	Title       string    `gorm:"not null;index" json:"title"`
-	ReleaseDate time.Time `gorm:"not null" json:"release_date"`
-	Text        string    `gorm:"type:text;not null" json:"text"`
-	Link        string    `gorm:"not null" json:"link"`
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	PublicID    uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null" json:"public_id"`
+	Slug        string     `gorm:"uniqueIndex;not null" json:"slug"`
+	Group       string     `gorm:"not null" json:"group"`
+	Title       string     `gorm:"not null;index" json:"title"`
+	ReleaseDate time.Time  `gorm:"not null" json:"release_date"`
+	Text        string     `gorm:"type:text;not null" json:"text"`
+	Link        string     `gorm:"not null" json:"link"`
+	SyncedAt    *time.Time `gorm:"index" json:"synced_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Provenance хранит источник каждого поля (release_date, text, link)
+	Provenance map[string]FieldProvenance `gorm:"type:jsonb;serializer:json" json:"provenance,omitempty"`
+}
+
+// SongSlug — прежний слаг песни, по которому выполняется перенаправление
+// на актуальный
+type SongSlug struct {
+	Slug      string `gorm:"primaryKey"`
+	SongID    uint   `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// SourceManual обозначает поле, отредактированное вручную
+const SourceManual = "manual"
+
+// FieldProvenance описывает происхождение поля песни.
+// Locked запрещает провайдерам перезаписывать поле.
+// @Description Field provenance
+type FieldProvenance struct {
+	Source    string     `json:"source"`
+	FetchedAt *time.Time `json:"fetched_at,omitempty"`
+	Locked    bool       `json:"locked"`
+}
+
+// IsLocked сообщает, защищено ли поле от перезаписи провайдером
+func (s Song) IsLocked(field string) bool {
+	return s.Provenance[field].Locked
+}
+
+// SetSource обновляет источник поля, сохраняя флаг блокировки
+func (s *Song) SetSource(field, source string, at time.Time) {
+	if s.Provenance == nil {
+		s.Provenance = make(map[string]FieldProvenance)
+	}
+	p := s.Provenance[field]
+	p.Source = source
+	p.FetchedAt = &at
+	s.Provenance[field] = p
 }
 
 func (s Song) GetVerses(page, pageSize int) []string {
-	verses := strings.Split(s.Text, "\n\n")
+	verses := s.Verses()
+	if page < 1 || pageSize < 1 {
+		return []string{}
+	}
 	start := (page - 1) * pageSize
 	end := page * pageSize
 
@@ -29,3 +78,11 @@ func (s Song) GetVerses(page, pageSize int) []string {
 	}
 	return verses[start:end]
 }
+
+// Verses возвращает все куплеты песни; у песни без текста куплетов нет
+func (s Song) Verses() []string {
+	if strings.TrimSpace(s.Text) == "" {
+		return []string{}
+	}
+	return strings.Split(s.Text, "\n\n")
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	SongChangePending  = "pending"
+	SongChangeApplied  = "applied"
+	SongChangeRejected = "rejected"
+)
+
+// SongChange представляет изменение поля песни, полученное от провайдера
+// @Description Song change queued by re-sync
+type SongChange struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	SongID    uint      `gorm:"not null;index" json:"song_id"`
+	Field     string    `gorm:"not null" json:"field"`
+	OldValue  string    `gorm:"type:text;not null" json:"old_value"`
+	NewValue  string    `gorm:"type:text;not null" json:"new_value"`
+	Source    string    `gorm:"not null;default:''" json:"source"`
+	Status    string    `gorm:"not null;index;default:pending" json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"math"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// listMultiplier — предполагаемая длина списка без аргумента size
+const listMultiplier = 10
+
+// complexity оценивает стоимость операции: каждое поле стоит 1,
+// а стоимость вложенных полей страницы умножается на size
+// (или на listMultiplier, если размер списка не ограничен).
+// Списки внутри страницы (items) уже учтены её размером.
+// Поля интроспекции не учитываются.
+func complexity(op *ast.OperationDefinition, vars map[string]interface{}) int {
+	return selectionCost(op.SelectionSet, vars, false)
+}
+
+func selectionCost(set ast.SelectionSet, vars map[string]interface{}, paged bool) int {
+	cost := 0
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *ast.Field:
+			cost = saturatingAdd(cost, fieldCost(s, vars, paged))
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				cost = saturatingAdd(cost, selectionCost(s.Definition.SelectionSet, vars, paged))
+			}
+		case *ast.InlineFragment:
+			cost = saturatingAdd(cost, selectionCost(s.SelectionSet, vars, paged))
+		}
+	}
+	return cost
+}
+
+func fieldCost(f *ast.Field, vars map[string]interface{}, paged bool) int {
+	if strings.HasPrefix(f.Name, "__") {
+		return 0
+	}
+
+	size, hasSize := pageSize(f, vars)
+	children := selectionCost(f.SelectionSet, vars, hasSize)
+	if children == 0 {
+		return 1
+	}
+
+	multiplier := 1
+	switch {
+	case hasSize:
+		multiplier = size
+	case !paged && f.Definition != nil && f.Definition.Type.Elem != nil:
+		multiplier = listMultiplier
+	}
+	return saturatingAdd(1, saturatingMul(multiplier, children))
+}
+
+// pageSize возвращает значение аргумента size поля или его значение по умолчанию
+func pageSize(f *ast.Field, vars map[string]interface{}) (int, bool) {
+	if arg := f.Arguments.ForName("size"); arg != nil {
+		if value, err := arg.Value.Value(vars); err == nil {
+			if n, ok := toInt(value); ok {
+				return n, true
+			}
+		}
+	}
+	if f.Definition == nil {
+		return 0, false
+	}
+	if def := f.Definition.Arguments.ForName("size"); def != nil && def.DefaultValue != nil {
+		if value, err := def.DefaultValue.Value(nil); err == nil {
+			if n, ok := toInt(value); ok {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int64:
+		return clampInt(float64(v)), true
+	case int:
+		return clampInt(float64(v)), true
+	case float64:
+		return clampInt(v), true
+	}
+	return 0, false
+}
+
+func clampInt(v float64) int {
+	if v < 1 {
+		return 1
+	}
+	if v > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(v)
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+func saturatingMul(a, b int) int {
+	if a != 0 && b > math.MaxInt32/a {
+		return math.MaxInt32
+	}
+	return a * b
+}
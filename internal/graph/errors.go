@@ -0,0 +1,40 @@
+package graph
+
+import (
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+)
+
+// resolverError передаёт клиенту код и поля доменной ошибки
+// в extensions ответа GraphQL
+type resolverError struct {
+	problem apperror.Problem
+	err     error
+}
+
+func (e *resolverError) Error() string {
+	return e.problem.Detail
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{
+		"code":   e.problem.Code,
+		"status": e.problem.Status,
+	}
+	if len(e.problem.Errors) > 0 {
+		extensions["fields"] = e.problem.Errors
+	}
+	return extensions
+}
+
+// graphError переводит ошибку сервиса в ошибку резолвера.
+// Детали неизвестных ошибок клиенту не раскрываются.
+func graphError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &resolverError{problem: apperror.NewProblem(err, "", ""), err: err}
+}
@@ -0,0 +1,237 @@
+// Package graph обслуживает эндпоинт GraphQL поверх SongService.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	defaultMaxComplexity = 1000
+	defaultMaxDepth      = 10
+	maxRequestSize       = 1 << 20
+)
+
+type Handler struct {
+	schema        *graphql.Schema
+	astSchema     *ast.Schema
+	songs         *service.SongService
+	maxComplexity int
+	logger        *logrus.Logger
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type response struct {
+	Data   json.RawMessage      `json:"data,omitempty"`
+	Errors []*errors.QueryError `json:"errors,omitempty"`
+}
+
+// RateLimitFunc списывает запрос из бюджета budget. Ошибка означает,
+// что бюджет исчерпан.
+type RateLimitFunc func(budget service.RateLimitBudget) error
+
+type rateLimitKey struct{}
+
+// WithRateLimit передаёт обработчику функцию ограничения частоты. Бюджет
+// известен только после разбора операции: запросы расходуют бюджет чтения,
+// мутации — бюджет записи.
+func WithRateLimit(ctx context.Context, take RateLimitFunc) context.Context {
+	return context.WithValue(ctx, rateLimitKey{}, take)
+}
+
+func NewHandler(songs *service.SongService, cfg *config.Config, log *logrus.Logger) (*Handler, error) {
+	maxDepth := cfg.GraphQL.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxDepth
+	}
+	maxComplexity := cfg.GraphQL.MaxComplexity
+	if maxComplexity <= 0 {
+		maxComplexity = defaultMaxComplexity
+	}
+
+	schema, err := graphql.ParseSchema(
+		schemaSDL,
+		&Resolver{songs: songs, logger: log},
+		graphql.UseFieldResolvers(),
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GraphQL schema: %w", err)
+	}
+
+	astSchema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+	if err != nil {
+		return nil, fmt.Errorf("invalid GraphQL schema: %w", err)
+	}
+
+	return &Handler{
+		schema:        schema,
+		astSchema:     astSchema,
+		songs:         songs,
+		maxComplexity: maxComplexity,
+		logger:        log,
+	}, nil
+}
+
+// ServeHTTP выполняет запрос GraphQL. GET допускает только запросы на чтение.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeRequest(r)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, &response{Errors: []*errors.QueryError{errors.Errorf("%s", err)}})
+		return
+	}
+
+	// запрос, который не удалось разобрать для проверки метода и сложности,
+	// не выполняется: иначе расхождение двух парсеров обходило бы проверки
+	doc, gqlErrs := gqlparser.LoadQuery(h.astSchema, req.Query)
+	if len(gqlErrs) > 0 {
+		writeResponse(w, http.StatusOK, &response{Errors: queryErrors(gqlErrs)})
+		return
+	}
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		queryErr := errors.Errorf("operation %q not found", req.OperationName)
+		if req.OperationName == "" {
+			queryErr = errors.Errorf("operationName is required for a document with several operations")
+		}
+		writeResponse(w, http.StatusOK, &response{Errors: []*errors.QueryError{queryErr}})
+		return
+	}
+
+	if op.Operation != ast.Query && r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeResponse(w, http.StatusMethodNotAllowed, &response{Errors: []*errors.QueryError{
+			errors.Errorf("%s operations require POST", op.Operation),
+		}})
+		return
+	}
+
+	if err := takeRateLimit(r.Context(), op); err != nil {
+		if appErr, ok := apperror.As(err); ok && appErr.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+		}
+		re := &resolverError{problem: apperror.NewProblem(err, "", ""), err: err}
+		queryErr := errors.Errorf("%s", re.Error())
+		queryErr.Extensions = re.Extensions()
+		writeResponse(w, re.problem.Status, &response{Errors: []*errors.QueryError{queryErr}})
+		return
+	}
+
+	if cost := complexity(op, req.Variables); cost > h.maxComplexity {
+		queryErr := errors.Errorf("query complexity %d exceeds the limit of %d", cost, h.maxComplexity)
+		queryErr.Extensions = map[string]interface{}{
+			"code":       "complexity_limit_exceeded",
+			"complexity": cost,
+			"limit":      h.maxComplexity,
+		}
+		writeResponse(w, http.StatusOK, &response{Errors: []*errors.QueryError{queryErr}})
+		return
+	}
+
+	h.exec(w, r, req)
+}
+
+func (h *Handler) exec(w http.ResponseWriter, r *http.Request, req *request) {
+	ctx := withLoaders(r.Context(), newLoaders(h.songs))
+	result := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	for _, queryErr := range result.Errors {
+		if re, ok := queryErr.ResolverError.(*resolverError); ok && re.problem.Status >= http.StatusInternalServerError {
+			h.logger.WithFields(logrus.Fields{
+				"error": re.err,
+				"path":  queryErr.Path,
+			}).Error("GraphQL resolver failed")
+		}
+	}
+
+	writeResponse(w, http.StatusOK, &response{Data: result.Data, Errors: result.Errors})
+}
+
+// takeRateLimit списывает операцию из бюджета, соответствующего её типу
+func takeRateLimit(ctx context.Context, op *ast.OperationDefinition) error {
+	take, ok := ctx.Value(rateLimitKey{}).(RateLimitFunc)
+	if !ok {
+		return nil
+	}
+	if op.Operation == ast.Mutation {
+		return take(service.BudgetWrite)
+	}
+	return take(service.BudgetRead)
+}
+
+// queryErrors переводит ошибки gqlparser в формат ответа graphql-go
+func queryErrors(list gqlerror.List) []*errors.QueryError {
+	queryErrs := make([]*errors.QueryError, 0, len(list))
+	for _, e := range list {
+		queryErr := &errors.QueryError{Message: e.Message, Extensions: e.Extensions}
+		for _, loc := range e.Locations {
+			queryErr.Locations = append(queryErr.Locations, errors.Location{Line: loc.Line, Column: loc.Column})
+		}
+		queryErrs = append(queryErrs, queryErr)
+	}
+	return queryErrs
+}
+
+func decodeRequest(r *http.Request) (*request, error) {
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return nil, fmt.Errorf("variables must be a JSON object: %w", err)
+			}
+		}
+	case http.MethodPost:
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		if len(body) > maxRequestSize {
+			return nil, fmt.Errorf("request body exceeds %d bytes", maxRequestSize)
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, fmt.Errorf("request body must be a JSON object: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("method %s is not supported", r.Method)
+	}
+
+	if req.Query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	return &req, nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp *response) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
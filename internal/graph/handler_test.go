@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+// TestServeHTTPRejectsBeforeExec проверяет отказы, которые отдаются до
+// выполнения запроса: у обработчика нет SongService, поэтому выполнение
+// любого из этих запросов завершилось бы ошибкой резолвера
+func TestServeHTTPRejectsBeforeExec(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	cfg := &config.Config{GraphQL: config.GraphQL{MaxComplexity: 50}}
+	h, err := NewHandler(nil, cfg, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		query   string
+		opName  string
+		status  int
+		message string
+	}{
+		{"синтаксическая ошибка", http.MethodPost, `mutation { deleteSong(id: "1") `, "", http.StatusOK, "Expected Name"},
+		{"неизвестное поле", http.MethodPost, `mutation { dropSongs }`, "", http.StatusOK, `Cannot query field "dropSongs"`},
+		{"неизвестная операция", http.MethodPost, `mutation Del { deleteSong(id: "1") }`, "Other", http.StatusOK, `operation "Other" not found`},
+		{"несколько операций без имени", http.MethodPost,
+			`query A { song(id: "1") { id } } mutation B { deleteSong(id: "1") }`, "", http.StatusOK, "operationName is required"},
+		{"мутация через GET", http.MethodGet, `mutation { deleteSong(id: "1") }`, "", http.StatusMethodNotAllowed, "require POST"},
+		{"превышена сложность", http.MethodPost, `{ songs(size: 100) { items { id } } }`, "", http.StatusOK, "exceeds the limit"},
+	}
+	for _, tt := range tests {
+		var r *http.Request
+		if tt.method == http.MethodGet {
+			params := url.Values{"query": {tt.query}, "operationName": {tt.opName}}
+			r = httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+		} else {
+			body, _ := json.Marshal(request{Query: tt.query, OperationName: tt.opName})
+			r = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.status)
+		}
+		var resp struct {
+			Data   json.RawMessage `json:"data"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Errorf("%s: invalid response %q: %v", tt.name, w.Body.String(), err)
+			continue
+		}
+		if resp.Data != nil {
+			t.Errorf("%s: response has data %s, want none", tt.name, resp.Data)
+		}
+		if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, tt.message) {
+			t.Errorf("%s: errors = %+v, want message containing %q", tt.name, resp.Errors, tt.message)
+		}
+	}
+}
+
+func TestServeHTTPRateLimitBudget(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	h, err := NewHandler(nil, &config.Config{}, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  service.RateLimitBudget
+	}{
+		{"запрос через POST", `{ song(id: "1") { id } }`, service.BudgetRead},
+		{"мутация", `mutation { deleteSong(id: "1") }`, service.BudgetWrite},
+	}
+	for _, tt := range tests {
+		var got service.RateLimitBudget
+		// исчерпанный бюджет останавливает запрос до выполнения
+		ctx := WithRateLimit(context.Background(), func(budget service.RateLimitBudget) error {
+			got = budget
+			return apperror.RateLimited("rate_limited", "Rate limit exceeded", 1500*time.Millisecond)
+		})
+		body, _ := json.Marshal(request{Query: tt.query})
+		r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))).WithContext(ctx)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if got != tt.want {
+			t.Errorf("%s: budget = %q, want %q", tt.name, got, tt.want)
+		}
+		if w.Code != http.StatusTooManyRequests {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, http.StatusTooManyRequests)
+		}
+		if retry := w.Header().Get("Retry-After"); retry != "2" {
+			t.Errorf("%s: Retry-After = %q, want %q", tt.name, retry, "2")
+		}
+		if !strings.Contains(w.Body.String(), `"code":"rate_limited"`) {
+			t.Errorf("%s: body = %s, want rate_limited code", tt.name, w.Body.String())
+		}
+	}
+}
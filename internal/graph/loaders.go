@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaderWait — время, за которое загрузчик собирает ключи в один запрос
+const loaderWait = 2 * time.Millisecond
+
+type loadersKey struct{}
+
+// loaders собирает обращения резолверов к исполнителям в пакетные запросы,
+// чтобы список из N исполнителей не порождал N запросов к базе.
+// Загрузчики создаются на каждый запрос и кешируют результаты только в нём.
+type loaders struct {
+	songsByArtist *dataloader.Loader[string, []entity.Song]
+	songCount     *dataloader.Loader[string, int64]
+}
+
+func newLoaders(songs *service.SongService) *loaders {
+	return &loaders{
+		songsByArtist: dataloader.NewBatchedLoader(
+			func(ctx context.Context, names []string) []*dataloader.Result[[]entity.Song] {
+				byName, err := songs.GetSongsByArtists(ctx, names)
+				results := make([]*dataloader.Result[[]entity.Song], len(names))
+				for i, name := range names {
+					results[i] = &dataloader.Result[[]entity.Song]{Data: byName[name], Error: err}
+				}
+				return results
+			},
+			dataloader.WithWait[string, []entity.Song](loaderWait),
+		),
+		songCount: dataloader.NewBatchedLoader(
+			func(ctx context.Context, names []string) []*dataloader.Result[int64] {
+				byName, err := songs.CountSongsByArtists(ctx, names)
+				results := make([]*dataloader.Result[int64], len(names))
+				for i, name := range names {
+					results[i] = &dataloader.Result[int64]{Data: byName[name], Error: err}
+				}
+				return results
+			},
+			dataloader.WithWait[string, int64](loaderWait),
+		),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
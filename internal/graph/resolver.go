@@ -0,0 +1,264 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/DusmatzodaQurbonli/song-library/internal/validation"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sirupsen/logrus"
+)
+
+// Resolver — корневой резолвер запросов и мутаций. Права на мутации
+// проверяет SongService.
+type Resolver struct {
+	songs  *service.SongService
+	logger *logrus.Logger
+}
+
+type pageArgs struct {
+	Page int32 `json:"page" binding:"min=1"`
+	Size int32 `json:"size" binding:"min=1,max=100"`
+}
+
+type songFilter struct {
+	Group *string `json:"group" binding:"omitempty,max=255"`
+	Title *string `json:"title" binding:"omitempty,max=255"`
+}
+
+func (f *songFilter) toMap() map[string]string {
+	filter := make(map[string]string)
+	if f == nil {
+		return filter
+	}
+	if f.Group != nil && *f.Group != "" {
+		filter["group"] = *f.Group
+	}
+	if f.Title != nil && *f.Title != "" {
+		filter["title"] = *f.Title
+	}
+	return filter
+}
+
+type songOrder struct {
+	Field     string
+	Direction string
+}
+
+func (o *songOrder) toOrder() service.SongOrder {
+	if o == nil {
+		return service.SongOrder{}
+	}
+	return service.SongOrder{
+		Field: strings.ToLower(o.Field),
+		Desc:  o.Direction == "DESC",
+	}
+}
+
+type addSongInput struct {
+	Group string `json:"group" binding:"required,max=255"`
+	Title string `json:"title" binding:"required,max=255"`
+}
+
+type updateSongInput struct {
+	Group       string       `json:"group" binding:"required,max=255"`
+	Title       string       `json:"title" binding:"required,max=255"`
+	ReleaseDate graphql.Time `json:"releaseDate"`
+	Text        string       `json:"text" binding:"required,max=20000"`
+	Link        string       `json:"link" binding:"required,url,max=2048"`
+}
+
+// Songs возвращает страницу песен с фильтрацией и сортировкой
+func (r *Resolver) Songs(ctx context.Context, args struct {
+	Filter  *songFilter
+	OrderBy *songOrder
+	Page    int32
+	Size    int32
+}) (*songPage, error) {
+	page := pageArgs{Page: args.Page, Size: args.Size}
+	if err := validate(page); err != nil {
+		return nil, err
+	}
+	if args.Filter != nil {
+		if err := validate(*args.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	songs, total, err := r.songs.GetSongs(ctx, args.Filter.toMap(), args.OrderBy.toOrder(), nil, int(page.Page), int(page.Size))
+	if err != nil {
+		return nil, graphError(err)
+	}
+
+	return &songPage{Items: newSongResolvers(songs), Pagination: newPagination(page, total)}, nil
+}
+
+// Song возвращает песню по ID или публичному ID либо null, если её нет
+func (r *Resolver) Song(ctx context.Context, args struct{ ID graphql.ID }) (*songResolver, error) {
+	id, err := r.songs.ResolveSongID(ctx, string(args.ID))
+	if apperror.IsKind(err, apperror.KindNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, graphError(err)
+	}
+
+	song, err := r.songs.GetSong(ctx, id)
+	if apperror.IsKind(err, apperror.KindNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, graphError(err)
+	}
+	return &songResolver{song: song}, nil
+}
+
+// SongBySlug возвращает песню по текущему или прежнему слагу либо null
+func (r *Resolver) SongBySlug(ctx context.Context, args struct{ Slug string }) (*songResolver, error) {
+	song, _, err := r.songs.GetSongBySlug(ctx, args.Slug)
+	if apperror.IsKind(err, apperror.KindNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, graphError(err)
+	}
+	return &songResolver{song: song}, nil
+}
+
+// Artists возвращает страницу исполнителей
+func (r *Resolver) Artists(ctx context.Context, args pageArgs) (*artistPage, error) {
+	if err := validate(args); err != nil {
+		return nil, err
+	}
+
+	artists, total, err := r.songs.GetArtists(ctx, int(args.Page), int(args.Size))
+	if err != nil {
+		return nil, graphError(err)
+	}
+
+	items := make([]*artistResolver, 0, len(artists))
+	for _, a := range artists {
+		count := a.SongCount
+		items = append(items, &artistResolver{name: a.Name, songCount: &count})
+	}
+	return &artistPage{Items: items, Pagination: newPagination(args, total)}, nil
+}
+
+// Artist возвращает исполнителя по имени или null, если у него нет песен
+func (r *Resolver) Artist(ctx context.Context, args struct{ Name string }) (*artistResolver, error) {
+	count, err := loadersFrom(ctx).songCount.Load(ctx, args.Name)()
+	if err != nil {
+		return nil, graphError(err)
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return &artistResolver{name: args.Name, songCount: &count}, nil
+}
+
+// AddSong добавляет песню, запрашивая сведения у провайдеров
+func (r *Resolver) AddSong(ctx context.Context, args struct{ Input addSongInput }) (*songResolver, error) {
+	if err := validate(args.Input); err != nil {
+		return nil, err
+	}
+
+	song, err := r.songs.AddSong(ctx, &entity.Song{Group: args.Input.Group, Title: args.Input.Title})
+	if err != nil {
+		return nil, graphError(err)
+	}
+	return &songResolver{song: song}, nil
+}
+
+// UpdateSong обновляет данные песни
+func (r *Resolver) UpdateSong(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input updateSongInput
+}) (*songResolver, error) {
+	id, err := r.songs.ResolveSongID(ctx, string(args.ID))
+	if err != nil {
+		return nil, graphError(err)
+	}
+	if err := validate(args.Input); err != nil {
+		return nil, err
+	}
+
+	song := &entity.Song{
+		ID:          id,
+		Group:       args.Input.Group,
+		Title:       args.Input.Title,
+		ReleaseDate: args.Input.ReleaseDate.Time,
+		Text:        args.Input.Text,
+		Link:        args.Input.Link,
+	}
+	if err := r.songs.UpdateSong(ctx, song); err != nil {
+		return nil, graphError(err)
+	}
+	return &songResolver{song: song}, nil
+}
+
+// DeleteSong удаляет песню и возвращает её ID
+func (r *Resolver) DeleteSong(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	id, err := r.songs.ResolveSongID(ctx, string(args.ID))
+	if err != nil {
+		return "", graphError(err)
+	}
+	if err := r.songs.DeleteSong(ctx, id); err != nil {
+		return "", graphError(err)
+	}
+	return args.ID, nil
+}
+
+// validate проверяет аргументы по тегам binding, как тела REST-запросов
+func validate(args any) error {
+	if err := validation.Struct(args); err != nil {
+		return graphError(err)
+	}
+	return nil
+}
+
+type pagination struct {
+	Page       int32
+	Size       int32
+	Total      int32
+	TotalPages int32
+}
+
+func newPagination(args pageArgs, total int64) pagination {
+	return pagination{
+		Page:       args.Page,
+		Size:       args.Size,
+		Total:      int32(total),
+		TotalPages: int32((total + int64(args.Size) - 1) / int64(args.Size)),
+	}
+}
+
+type songPage struct {
+	Items      []*songResolver
+	Pagination pagination
+}
+
+type artistPage struct {
+	Items      []*artistResolver
+	Pagination pagination
+}
+
+type versePage struct {
+	Items      []string
+	Pagination pagination
+}
+
+func newSongResolvers(songs []entity.Song) []*songResolver {
+	resolvers := make([]*songResolver, 0, len(songs))
+	for i := range songs {
+		resolvers = append(resolvers, &songResolver{song: &songs[i]})
+	}
+	return resolvers
+}
+
+func formatID(id uint) graphql.ID {
+	return graphql.ID(fmt.Sprint(id))
+}
@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/lyrics"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type songResolver struct {
+	song *entity.Song
+}
+
+func (r *songResolver) ID() graphql.ID          { return formatID(r.song.ID) }
+func (r *songResolver) PublicID() graphql.ID    { return graphql.ID(r.song.PublicID.String()) }
+func (r *songResolver) Slug() string            { return r.song.Slug }
+func (r *songResolver) Group() string           { return r.song.Group }
+func (r *songResolver) Title() string           { return r.song.Title }
+func (r *songResolver) Text() string            { return r.song.Text }
+func (r *songResolver) Link() string            { return r.song.Link }
+func (r *songResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.song.CreatedAt} }
+func (r *songResolver) UpdatedAt() graphql.Time { return graphql.Time{Time: r.song.UpdatedAt} }
+
+func (r *songResolver) ReleaseDate() *graphql.Time {
+	return optionalTime(r.song.ReleaseDate)
+}
+
+func (r *songResolver) SyncedAt() *graphql.Time {
+	if r.song.SyncedAt == nil {
+		return nil
+	}
+	return optionalTime(*r.song.SyncedAt)
+}
+
+func (r *songResolver) Artist() *artistResolver {
+	return &artistResolver{name: r.song.Group}
+}
+
+// Verses возвращает страницу куплетов без обращения к базе
+func (r *songResolver) Verses(args pageArgs) (*versePage, error) {
+	if err := validate(args); err != nil {
+		return nil, err
+	}
+	total := len(r.song.Verses())
+	return &versePage{
+		Items:      r.song.GetVerses(int(args.Page), int(args.Size)),
+		Pagination: newPagination(args, int64(total)),
+	}, nil
+}
+
+func (r *songResolver) Stanzas(args struct{ Collapse bool }) []*stanza {
+	stanzas := lyrics.ParseStanzas(r.song.Text)
+	if args.Collapse {
+		stanzas = lyrics.Collapse(stanzas)
+	}
+
+	result := make([]*stanza, 0, len(stanzas))
+	for i, s := range stanzas {
+		result = append(result, newStanza(i, s))
+	}
+	return result
+}
+
+// artistResolver загружает число песен и сами песни пакетно через loaders,
+// если они не получены вместе со списком исполнителей
+type artistResolver struct {
+	name      string
+	songCount *int64
+}
+
+func (r *artistResolver) Name() string { return r.name }
+
+func (r *artistResolver) SongCount(ctx context.Context) (int32, error) {
+	if r.songCount != nil {
+		return int32(*r.songCount), nil
+	}
+	count, err := loadersFrom(ctx).songCount.Load(ctx, r.name)()
+	if err != nil {
+		return 0, graphError(err)
+	}
+	return int32(count), nil
+}
+
+func (r *artistResolver) Songs(ctx context.Context) ([]*songResolver, error) {
+	songs, err := loadersFrom(ctx).songsByArtist.Load(ctx, r.name)()
+	if err != nil {
+		return nil, graphError(err)
+	}
+	return newSongResolvers(songs), nil
+}
+
+type stanza struct {
+	Index    int32
+	Role     string
+	Number   int32
+	Label    *string
+	Lines    []string
+	RepeatOf *int32
+}
+
+func newStanza(index int, s lyrics.Stanza) *stanza {
+	result := &stanza{
+		Index:  int32(index),
+		Role:   strings.ToUpper(strings.ReplaceAll(string(s.Role), "-", "_")),
+		Number: int32(s.Number),
+		Lines:  s.Lines,
+	}
+	if result.Lines == nil {
+		result.Lines = []string{}
+	}
+	if s.Label != "" {
+		result.Label = &s.Label
+	}
+	if s.RepeatOf != nil {
+		repeatOf := int32(*s.RepeatOf)
+		result.RepeatOf = &repeatOf
+	}
+	return result
+}
+
+func optionalTime(t time.Time) *graphql.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: t}
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type APIKeyHandler struct {
+	service *service.APIKeyService
+	logger  *logrus.Logger
+}
+
+func NewAPIKeyHandler(s *service.APIKeyService, log *logrus.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: s,
+		logger:  log,
+	}
+}
+
+// @Summary List API keys
+// @Description List all API keys including revoked ones. Secrets are never returned.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {array} APIKeyResponse "API keys"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the admin permission"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /admin/keys [get]
+func (h *APIKeyHandler) GetKeys(c *gin.Context) {
+	keys, err := h.service.GetKeys(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := make([]APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		responses = append(responses, newAPIKeyResponse(&keys[i]))
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// @Summary Create API key
+// @Description Create an API key. The key is returned only in this response.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param key body CreateAPIKeyRequest true "Key name, owner, role and optional expiry"
+// @Success 201 {object} APIKeySecretResponse "Created key with its secret"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the admin permission"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /admin/keys [post]
+func (h *APIKeyHandler) CreateKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	key, secret, err := h.service.CreateKey(c.Request.Context(), service.NewAPIKey{
+		Name:      req.Name,
+		Owner:     req.Owner,
+		Role:      req.Role,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, APIKeySecretResponse{APIKeyResponse: newAPIKeyResponse(key), Key: secret})
+}
+
+// @Summary Get API key
+// @Description Get an API key by ID. The secret is never returned.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 200 {object} APIKeyResponse "API key"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the admin permission"
+// @Failure 404 {object} apperror.Problem "API key not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /admin/keys/{id} [get]
+func (h *APIKeyHandler) GetKey(c *gin.Context) {
+	var param IDParam
+	if err := bindURI(c, &param); err != nil {
+		c.Error(err)
+		return
+	}
+
+	key, err := h.service.GetKey(c.Request.Context(), param.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAPIKeyResponse(key))
+}
+
+// @Summary Rotate API key
+// @Description Issue a new secret for the key; the previous secret stops working immediately.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 200 {object} APIKeySecretResponse "Key with its new secret"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the admin permission"
+// @Failure 404 {object} apperror.Problem "API key not found"
+// @Failure 409 {object} apperror.Problem "API key is revoked"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /admin/keys/{id}/rotate [post]
+func (h *APIKeyHandler) RotateKey(c *gin.Context) {
+	var param IDParam
+	if err := bindURI(c, &param); err != nil {
+		c.Error(err)
+		return
+	}
+
+	key, secret, err := h.service.RotateKey(c.Request.Context(), param.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIKeySecretResponse{APIKeyResponse: newAPIKeyResponse(key), Key: secret})
+}
+
+// @Summary Revoke API key
+// @Description Revoke an API key. The key record is kept for audit.
+// @Tags admin
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the admin permission"
+// @Failure 404 {object} apperror.Problem "API key not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /admin/keys/{id} [delete]
+func (h *APIKeyHandler) RevokeKey(c *gin.Context) {
+	var param IDParam
+	if err := bindURI(c, &param); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if _, err := h.service.RevokeKey(c.Request.Context(), param.ID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setLastModified выставляет Last-Modified, по которому сервер
+// отвечает 304 на запросы с If-Modified-Since
+func setLastModified(c *gin.Context, t time.Time) {
+	if !t.IsZero() {
+		c.Header("Last-Modified", t.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,426 @@
+package handler
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/lyrics"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/DusmatzodaQurbonli/song-library/internal/validation"
+	"github.com/google/uuid"
+)
+
+// SongResponse — представление песни в ответах API
+// @Description Song
+type SongResponse struct {
+	ID          uint                              `json:"id"`
+	PublicID    uuid.UUID                         `json:"public_id"`
+	Slug        string                            `json:"slug"`
+	Group       string                            `json:"group"`
+	Title       string                            `json:"title"`
+	ReleaseDate time.Time                         `json:"release_date"`
+	Text        string                            `json:"text"`
+	Link        string                            `json:"link"`
+	SyncedAt    *time.Time                        `json:"synced_at,omitempty"`
+	Provenance  map[string]entity.FieldProvenance `json:"provenance,omitempty"`
+	CreatedAt   time.Time                         `json:"created_at"`
+	UpdatedAt   time.Time                         `json:"updated_at"`
+}
+
+func newSongResponse(song *entity.Song) SongResponse {
+	return SongResponse{
+		ID:          song.ID,
+		PublicID:    song.PublicID,
+		Slug:        song.Slug,
+		Group:       song.Group,
+		Title:       song.Title,
+		ReleaseDate: song.ReleaseDate,
+		Text:        song.Text,
+		Link:        song.Link,
+		SyncedAt:    song.SyncedAt,
+		Provenance:  song.Provenance,
+		CreatedAt:   song.CreatedAt,
+		UpdatedAt:   song.UpdatedAt,
+	}
+}
+
+// IDParam — идентификатор ресурса в пути
+type IDParam struct {
+	ID uint `uri:"id" binding:"required,min=1"`
+}
+
+// SongIDParam — ссылка на песню в пути: числовой ID или публичный UUID
+type SongIDParam struct {
+	ID string `uri:"id" binding:"required,max=64"`
+}
+
+// SlugParam — слаг песни в пути: /songs/by-slug/{artist}/{title}
+type SlugParam struct {
+	Artist string `uri:"artist" binding:"required,max=255"`
+	Title  string `uri:"title" binding:"required,max=255"`
+}
+
+// SongID — ссылка на песню в JSON: числовой ID числом
+// или публичный UUID строкой
+type SongID string
+
+func (id *SongID) UnmarshalJSON(data []byte) error {
+	var n uint64
+	if err := json.Unmarshal(data, &n); err == nil {
+		*id = SongID(strconv.FormatUint(n, 10))
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*id = SongID(s)
+	return nil
+}
+
+func (id SongID) MarshalJSON() ([]byte, error) {
+	if n, err := strconv.ParseUint(string(id), 10, 64); err == nil {
+		return json.Marshal(n)
+	}
+	return json.Marshal(string(id))
+}
+
+func newSongIDs(refs []service.SongRef) []SongID {
+	ids := make([]SongID, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, SongID(ref.String()))
+	}
+	return ids
+}
+
+// SongListQuery — параметры списка песен
+type SongListQuery struct {
+	validation.PageQuery
+	Group string `form:"group" binding:"max=255"`
+	Title string `form:"title" binding:"max=255"`
+	IDs   string `form:"ids" binding:"max=1200"`
+	SongProjectionQuery
+}
+
+// maxQueryIDs ограничивает число ID в параметре ids=; длинные списки
+// передаются в теле POST /songs/batch
+const maxQueryIDs = 100
+
+// maxBatchIDs ограничивает число ID в теле POST /songs/batch
+const maxBatchIDs = 1000
+
+// SongProjectionQuery — выбор полей и встраиваемых ресурсов
+type SongProjectionQuery struct {
+	Fields  string `form:"fields" binding:"max=512"`
+	Include string `form:"include" binding:"max=255"`
+}
+
+// SongBatchRequest — тело запроса на получение песен по списку ID.
+// Каждый элемент — числовой ID или публичный UUID.
+// @Description Batch of song IDs
+type SongBatchRequest struct {
+	IDs []SongID `json:"ids" binding:"required,min=1,max=1000" swaggertype:"array,string"`
+}
+
+func (q SongListQuery) filter() map[string]string {
+	filter := make(map[string]string)
+	if q.Group != "" {
+		filter["group"] = q.Group
+	}
+	if q.Title != "" {
+		filter["title"] = q.Title
+	}
+	return filter
+}
+
+// TimedWordRequest — слово строки с отметкой времени в секундах
+type TimedWordRequest struct {
+	Start *float64 `json:"start" binding:"required,min=0,max=86400"`
+	Text  string   `json:"text" binding:"required,max=255"`
+}
+
+// TimedLineRequest — строка текста с отметкой времени в секундах
+type TimedLineRequest struct {
+	Start *float64           `json:"start" binding:"required,min=0,max=86400"`
+	Text  string             `json:"text" binding:"max=1000"`
+	Words []TimedWordRequest `json:"words" binding:"omitempty,dive"`
+}
+
+// TimingRequest — тело запроса на сохранение разметки песни
+// @Description Synced lyrics
+type TimingRequest struct {
+	Lines []TimedLineRequest `json:"lines" binding:"required,min=1,max=2000,dive"`
+}
+
+func (r TimingRequest) toLines() []lyrics.Line {
+	lines := make([]lyrics.Line, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		var words []lyrics.Word
+		for _, word := range line.Words {
+			words = append(words, lyrics.Word{Start: seconds(*word.Start), Text: word.Text})
+		}
+		lines = append(lines, lyrics.Line{Start: seconds(*line.Start), Text: line.Text, Words: words})
+	}
+	return lines
+}
+
+// TimedWordResponse — слово строки с отметкой времени в секундах
+type TimedWordResponse struct {
+	Start float64 `json:"start"`
+	Text  string  `json:"text"`
+}
+
+// TimedLineResponse — строка текста с отметкой времени в секундах
+type TimedLineResponse struct {
+	Start float64             `json:"start"`
+	Text  string              `json:"text"`
+	Words []TimedWordResponse `json:"words,omitempty"`
+}
+
+func newTimedLineResponse(line *lyrics.Line) *TimedLineResponse {
+	if line == nil {
+		return nil
+	}
+	response := &TimedLineResponse{Start: line.Start.Seconds(), Text: line.Text}
+	for _, word := range line.Words {
+		response.Words = append(response.Words, TimedWordResponse{Start: word.Start.Seconds(), Text: word.Text})
+	}
+	return response
+}
+
+// TimingResponse — разметка песни
+// @Description Synced lyrics
+type TimingResponse struct {
+	SongID uint                `json:"song_id"`
+	Lines  []TimedLineResponse `json:"lines"`
+}
+
+func newTimingResponse(songID uint, lines []lyrics.Line) TimingResponse {
+	response := TimingResponse{SongID: songID, Lines: make([]TimedLineResponse, 0, len(lines))}
+	for i := range lines {
+		response.Lines = append(response.Lines, *newTimedLineResponse(&lines[i]))
+	}
+	return response
+}
+
+// LyricsPositionResponse — строки вокруг позиции воспроизведения.
+// Word — индекс звучащего слова текущей строки, если есть разметка слов.
+// @Description Lyrics at playback position
+type LyricsPositionResponse struct {
+	T        float64            `json:"t"`
+	Index    int                `json:"index"`
+	Previous *TimedLineResponse `json:"previous"`
+	Current  *TimedLineResponse `json:"current"`
+	Next     *TimedLineResponse `json:"next"`
+	Word     *int               `json:"word,omitempty"`
+}
+
+func newLyricsPositionResponse(t float64, p *service.LyricsPosition) LyricsPositionResponse {
+	response := LyricsPositionResponse{
+		T:        t,
+		Index:    p.Index,
+		Previous: newTimedLineResponse(p.Previous),
+		Current:  newTimedLineResponse(p.Current),
+		Next:     newTimedLineResponse(p.Next),
+	}
+	if p.Word >= 0 {
+		response.Word = &p.Word
+	}
+	return response
+}
+
+// LyricsAtQuery — позиция воспроизведения в секундах
+type LyricsAtQuery struct {
+	T *float64 `form:"t" binding:"required,min=0,max=86400"`
+}
+
+// LRCQuery — параметры выгрузки LRC
+type LRCQuery struct {
+	Enhanced bool `form:"enhanced"`
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Millisecond)
+}
+
+// StanzaQuery — параметры структуры текста
+type StanzaQuery struct {
+	Collapse bool `form:"collapse"`
+}
+
+// StanzaResponse — строфа текста с ролью.
+// RepeatOf — индекс первого вхождения повторённой строфы.
+type StanzaResponse struct {
+	Index    int         `json:"index"`
+	Role     lyrics.Role `json:"role" enums:"intro,verse,pre-chorus,chorus,bridge,outro"`
+	Number   int         `json:"number"`
+	Label    string      `json:"label,omitempty"`
+	Lines    []string    `json:"lines,omitempty"`
+	RepeatOf *int        `json:"repeat_of,omitempty"`
+}
+
+// StanzasResponse — текст песни, разобранный на строфы
+// @Description Structured lyrics
+type StanzasResponse struct {
+	SongID  uint             `json:"song_id"`
+	Stanzas []StanzaResponse `json:"stanzas"`
+}
+
+func newStanzasResponse(songID uint, stanzas []lyrics.Stanza) StanzasResponse {
+	response := StanzasResponse{SongID: songID, Stanzas: make([]StanzaResponse, 0, len(stanzas))}
+	for i, stanza := range stanzas {
+		response.Stanzas = append(response.Stanzas, StanzaResponse{
+			Index:    i,
+			Role:     stanza.Role,
+			Number:   stanza.Number,
+			Label:    stanza.Label,
+			Lines:    stanza.Lines,
+			RepeatOf: stanza.RepeatOf,
+		})
+	}
+	return response
+}
+
+// SongItem — песня в списке. Содержит только поля, выбранные в fields=,
+// и ресурсы, встроенные через include=
+// @Description Song with selected fields and embedded resources
+type SongItem struct {
+	ID          uint                              `json:"id"`
+	PublicID    *uuid.UUID                        `json:"public_id,omitempty"`
+	Slug        *string                           `json:"slug,omitempty"`
+	Group       *string                           `json:"group,omitempty"`
+	Title       *string                           `json:"title,omitempty"`
+	ReleaseDate *time.Time                        `json:"release_date,omitempty"`
+	Text        *string                           `json:"text,omitempty"`
+	Link        *string                           `json:"link,omitempty"`
+	SyncedAt    *time.Time                        `json:"synced_at,omitempty"`
+	Provenance  map[string]entity.FieldProvenance `json:"provenance,omitempty"`
+	CreatedAt   *time.Time                        `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time                        `json:"updated_at,omitempty"`
+
+	// Stanzas встраивается через include=stanzas
+	Stanzas []StanzaResponse `json:"stanzas,omitempty"`
+	// Timing встраивается через include=timing
+	Timing []TimedLineResponse `json:"timing,omitempty"`
+	// Artist встраивается через include=artist
+	Artist *ArtistResponse `json:"artist,omitempty"`
+}
+
+func newSongItem(song *entity.Song, p songProjection) SongItem {
+	item := SongItem{ID: song.ID}
+	if p.has("public_id") {
+		item.PublicID = &song.PublicID
+	}
+	if p.has("slug") {
+		item.Slug = &song.Slug
+	}
+	if p.has("group") {
+		item.Group = &song.Group
+	}
+	if p.has("title") {
+		item.Title = &song.Title
+	}
+	if p.has("release_date") {
+		item.ReleaseDate = &song.ReleaseDate
+	}
+	if p.has("text") {
+		item.Text = &song.Text
+	}
+	if p.has("link") {
+		item.Link = &song.Link
+	}
+	if p.has("synced_at") {
+		item.SyncedAt = song.SyncedAt
+	}
+	if p.has("provenance") {
+		item.Provenance = song.Provenance
+	}
+	if p.has("created_at") {
+		item.CreatedAt = &song.CreatedAt
+	}
+	if p.has("updated_at") {
+		item.UpdatedAt = &song.UpdatedAt
+	}
+	if p.includes(includeStanzas) {
+		item.Stanzas = newStanzasResponse(song.ID, lyrics.ParseStanzas(song.Text)).Stanzas
+	}
+	return item
+}
+
+// ArtistResponse — исполнитель и число его песен в библиотеке
+// @Description Artist
+type ArtistResponse struct {
+	Name      string `json:"name"`
+	SongCount int64  `json:"song_count"`
+}
+
+// SongListResponse — страница списка песен
+// @Description Page of songs
+type SongListResponse struct {
+	Items      []SongItem `json:"items"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// SongBatchResponse — песни в порядке запрошенных ID
+// и ID, для которых песни не нашлись
+// @Description Songs fetched by IDs
+type SongBatchResponse struct {
+	Items   []SongItem `json:"items"`
+	Missing []SongID   `json:"missing" swaggertype:"array,string"`
+}
+
+// VerseListResponse — страница куплетов песни
+// @Description Page of song verses
+type VerseListResponse struct {
+	Items      []string   `json:"items"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// CreateAPIKeyRequest — тело запроса на создание API-ключа
+// @Description Create API key request
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=255"`
+	Owner     string     `json:"owner" binding:"required,max=255"`
+	Role      string     `json:"role" binding:"required,oneof=viewer contributor editor admin"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyResponse — сведения об API-ключе без секрета
+// @Description API key
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Owner      string     `json:"owner"`
+	Prefix     string     `json:"prefix"`
+	Role       string     `json:"role"`
+	Active     bool       `json:"active"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func newAPIKeyResponse(key *entity.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Owner:      key.Owner,
+		Prefix:     key.Prefix,
+		Role:       key.Role,
+		Active:     key.Active(time.Now()),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// APIKeySecretResponse — API-ключ вместе с секретом. Секрет
+// показывается только в ответе на создание или ротацию.
+// @Description API key with its secret
+type APIKeySecretResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
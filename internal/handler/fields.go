@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// songFields перечисляет поля песни, доступные в fields=, в порядке вывода
+var songFields = []string{
+	"id", "public_id", "slug", "group", "title", "release_date", "text", "link",
+	"synced_at", "provenance", "created_at", "updated_at",
+}
+
+// Связанные ресурсы, которые можно встроить в песню через include=
+const (
+	includeLyrics  = "lyrics"
+	includeStanzas = "stanzas"
+	includeTiming  = "timing"
+	includeArtist  = "artist"
+)
+
+var songIncludes = []string{includeLyrics, includeStanzas, includeTiming, includeArtist}
+
+// songProjection описывает выбранные поля и встраиваемые ресурсы песни.
+// По умолчанию выводятся все поля, кроме текста: он попадает в ответ
+// только через fields=text или include=lyrics.
+type songProjection struct {
+	fields  map[string]bool
+	include map[string]bool
+}
+
+func newSongProjection(fields, include string) (songProjection, error) {
+	p := songProjection{
+		fields:  make(map[string]bool),
+		include: make(map[string]bool),
+	}
+	var errs []apperror.FieldError
+
+	for _, name := range splitList(fields) {
+		if !slices.Contains(songFields, name) {
+			errs = append(errs, apperror.FieldError{
+				Field:  "fields",
+				Reason: fmt.Sprintf("unknown field %q, must be one of %s", name, strings.Join(songFields, ", ")),
+			})
+			continue
+		}
+		p.fields[name] = true
+	}
+	for _, name := range splitList(include) {
+		if !slices.Contains(songIncludes, name) {
+			errs = append(errs, apperror.FieldError{
+				Field:  "include",
+				Reason: fmt.Sprintf("unknown resource %q, must be one of %s", name, strings.Join(songIncludes, ", ")),
+			})
+			continue
+		}
+		p.include[name] = true
+	}
+	if len(errs) > 0 {
+		return p, apperror.InvalidFields(errs, nil)
+	}
+
+	if len(p.fields) == 0 {
+		for _, name := range songFields {
+			p.fields[name] = name != "text"
+		}
+	}
+	if p.include[includeLyrics] {
+		p.fields["text"] = true
+	}
+	// ID выводится всегда
+	p.fields["id"] = true
+	return p, nil
+}
+
+func (p songProjection) has(field string) bool {
+	return p.fields[field]
+}
+
+func (p songProjection) includes(resource string) bool {
+	return p.include[resource]
+}
+
+// columns возвращает поля, которые нужно прочитать из базы: выбранные
+// и необходимые для встраиваемых ресурсов
+func (p songProjection) columns() []string {
+	needed := map[string]bool{
+		"text":  p.includes(includeStanzas),
+		"group": p.includes(includeArtist),
+	}
+	columns := make([]string, 0, len(songFields))
+	for _, name := range songFields {
+		if p.fields[name] || needed[name] {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы и повторы
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" && !slices.Contains(items, item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseSongIDs разбирает ссылки на песни из параметра ids=
+func parseSongIDs(field string, ids []string, limit int) ([]service.SongRef, error) {
+	if len(ids) > limit {
+		return nil, apperror.InvalidFields([]apperror.FieldError{{
+			Field:  field,
+			Reason: fmt.Sprintf("must contain at most %d items", limit),
+		}}, nil)
+	}
+
+	refs := make([]service.SongRef, 0, len(ids))
+	var errs []apperror.FieldError
+	for _, id := range ids {
+		ref, ok := service.ParseSongRef(id)
+		if !ok {
+			errs = append(errs, apperror.FieldError{
+				Field:  field,
+				Reason: fmt.Sprintf("%q is neither a song ID nor a public UUID", id),
+			})
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	if len(errs) > 0 {
+		return nil, apperror.InvalidFields(errs, nil)
+	}
+	return refs, nil
+}
+
+// songIDResolver находит внутренний ID песни по ссылке из запроса
+type songIDResolver interface {
+	ResolveSongID(ctx context.Context, ref string) (uint, error)
+}
+
+// bindSongID разбирает :id песни — числовой ID или публичный UUID —
+// и возвращает её внутренний ID
+func bindSongID(c *gin.Context, songs songIDResolver) (uint, error) {
+	var param SongIDParam
+	if err := bindURI(c, &param); err != nil {
+		return 0, err
+	}
+	return songs.ResolveSongID(c.Request.Context(), param.ID)
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestNewSongProjection(t *testing.T) {
+	allButText := slices.DeleteFunc(slices.Clone(songFields), func(f string) bool { return f == "text" })
+
+	tests := []struct {
+		name            string
+		fields, include string
+		want            []string
+		columns         []string
+	}{
+		{"по умолчанию все поля, кроме текста", "", "", allButText, allButText},
+		{"выбранные поля и id", "title,group", "", []string{"id", "group", "title"}, []string{"id", "group", "title"}},
+		{"пробелы и повторы", " title , ,title", "", []string{"id", "title"}, []string{"id", "title"}},
+		{"fields=text", "text", "", []string{"id", "text"}, []string{"id", "text"}},
+		{"include=lyrics добавляет текст", "title", "lyrics", []string{"id", "title", "text"}, []string{"id", "title", "text"}},
+		{"include=stanzas читает текст, но не выводит его", "title", "stanzas", []string{"id", "title"}, []string{"id", "title", "text"}},
+		{"include=artist читает исполнителя", "title", "artist", []string{"id", "title"}, []string{"id", "group", "title"}},
+		{"include=timing не требует полей", "id", "timing", []string{"id"}, []string{"id"}},
+	}
+	for _, tt := range tests {
+		p, err := newSongProjection(tt.fields, tt.include)
+		if err != nil {
+			t.Errorf("%s: newSongProjection() error = %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, f := range songFields {
+			if p.has(f) {
+				got = append(got, f)
+			}
+		}
+		if !sameItems(got, tt.want) {
+			t.Errorf("%s: fields = %v, want %v", tt.name, got, tt.want)
+		}
+		if columns := p.columns(); !sameItems(columns, tt.columns) {
+			t.Errorf("%s: columns() = %v, want %v", tt.name, columns, tt.columns)
+		}
+	}
+}
+
+func sameItems(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func TestNewSongProjectionErrors(t *testing.T) {
+	tests := []struct {
+		fields, include string
+		want            []string
+	}{
+		{"title,lyrics", "", []string{"fields"}},
+		{"", "comments", []string{"include"}},
+		{"passwd", "text", []string{"fields", "include"}},
+	}
+	for _, tt := range tests {
+		_, err := newSongProjection(tt.fields, tt.include)
+		appErr, ok := apperror.As(err)
+		if !ok || appErr.Kind != apperror.KindValidation {
+			t.Errorf("newSongProjection(%q, %q) error = %v, want validation error", tt.fields, tt.include, err)
+			continue
+		}
+		var got []string
+		for _, f := range appErr.Fields {
+			got = append(got, f.Field)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("newSongProjection(%q, %q) invalid fields = %v, want %v", tt.fields, tt.include, got, tt.want)
+		}
+	}
+}
+
+func TestNewSongItem(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	song := &entity.Song{
+		ID:          7,
+		PublicID:    uuid.MustParse("3f2c1a9e-8b7d-4c6e-9f0a-1b2c3d4e5f60"),
+		Slug:        "muse/uprising",
+		Group:       "Muse",
+		Title:       "Uprising",
+		ReleaseDate: now,
+		Text:        "Paranoia is in bloom\n\nThey will not force us",
+		Link:        "https://example.com/uprising",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	tests := []struct {
+		name            string
+		fields, include string
+		want            []string
+	}{
+		{"id и название", "title", "", []string{"id", "title"}},
+		{"текст", "text", "", []string{"id", "text"}},
+		{"строфы без текста", "id", "stanzas", []string{"id", "stanzas"}},
+		{"по умолчанию", "", "", []string{"id", "public_id", "slug", "group", "title", "release_date", "link", "created_at", "updated_at"}},
+	}
+	for _, tt := range tests {
+		p, err := newSongProjection(tt.fields, tt.include)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(newSongItem(song, p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var item map[string]any
+		if err := json.Unmarshal(data, &item); err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for key := range item {
+			got = append(got, key)
+		}
+		if !sameItems(got, tt.want) {
+			t.Errorf("%s: keys = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{" , ,", nil},
+		{"a,b", []string{"a", "b"}},
+		{" a , b ,a", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		if got := splitList(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitList(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseSongIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []string
+		limit   int
+		refs    int
+		invalid int
+	}{
+		{"ID и UUID", []string{"1", "3f2c1a9e-8b7d-4c6e-9f0a-1b2c3d4e5f60"}, 10, 2, 0},
+		{"пустой список", nil, 10, 0, 0},
+		{"ровно лимит", []string{"1", "2", "3"}, 3, 3, 0},
+		{"больше лимита", []string{"1", "2", "3", "4"}, 3, 0, 1},
+		{"каждый неверный ID отдельно", []string{"1", "abc", "0", "2"}, 10, 0, 2},
+	}
+	for _, tt := range tests {
+		refs, err := parseSongIDs("ids", tt.ids, tt.limit)
+		if tt.invalid == 0 {
+			if err != nil || len(refs) != tt.refs {
+				t.Errorf("%s: parseSongIDs() = %d refs, %v, want %d refs", tt.name, len(refs), err, tt.refs)
+			}
+			continue
+		}
+		appErr, ok := apperror.As(err)
+		if !ok || len(appErr.Fields) != tt.invalid {
+			t.Errorf("%s: parseSongIDs() error = %v, want %d invalid items", tt.name, err, tt.invalid)
+		}
+	}
+}
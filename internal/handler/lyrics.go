@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/lyrics"
+	"github.com/gin-gonic/gin"
+)
+
+// negotiateLyricsFormat выбирает формат текста по параметру format=
+// либо по заголовку Accept
+func negotiateLyricsFormat(c *gin.Context) (lyrics.Format, error) {
+	if name := c.Query("format"); name != "" {
+		format, ok := lyrics.ParseFormat(name)
+		if !ok {
+			return "", apperror.InvalidFields([]apperror.FieldError{{
+				Field:  "format",
+				Reason: "must be one of " + formatNames(),
+			}}, nil)
+		}
+		return format, nil
+	}
+
+	offered := make([]string, 0, len(lyrics.Formats))
+	for _, format := range lyrics.Formats {
+		offered = append(offered, format.ContentType())
+	}
+
+	format, ok := lyrics.FormatByContentType(c.NegotiateFormat(offered...))
+	if !ok {
+		return "", apperror.Validation(
+			"not_acceptable",
+			"Supported media types: "+strings.Join(offered, ", "),
+			nil,
+		).WithStatus(http.StatusNotAcceptable)
+	}
+	return format, nil
+}
+
+func formatNames() string {
+	names := make([]string, 0, len(lyrics.Formats))
+	for _, format := range lyrics.Formats {
+		names = append(names, string(format))
+	}
+	return strings.Join(names, ", ")
+}
+
+// renderLyrics отдаёт страницу куплетов песни в выбранном формате
+func renderLyrics(c *gin.Context, format lyrics.Format, song *entity.Song, page VerseListResponse) {
+	verses := page.Items
+	c.Writer.Header().Add("Vary", "Accept")
+
+	var body string
+	switch format {
+	case lyrics.FormatText:
+		body = lyrics.Text(verses)
+	case lyrics.FormatMarkdown:
+		body = lyrics.Markdown(song.Group, song.Title, verses)
+	case lyrics.FormatHTML:
+		body = lyrics.HTML(verses)
+	default:
+		c.JSON(http.StatusOK, page)
+		return
+	}
+
+	c.Data(http.StatusOK, format.ContentType()+"; charset=utf-8", []byte(body))
+}
+
+// renderLRC отдаёт разметку песни в формате LRC
+func (h *SongHandler) renderLRC(c *gin.Context, id uint) {
+	var query LRCQuery
+	if err := bindQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	song, lines, err := h.lyrics.GetTiming(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get song timing")
+		c.Error(err)
+		return
+	}
+	if len(lines) == 0 {
+		c.Error(apperror.Validation(
+			"lrc_unavailable",
+			"Song has no timing data for LRC",
+			nil,
+		).WithStatus(http.StatusNotAcceptable))
+		return
+	}
+
+	c.Writer.Header().Add("Vary", "Accept")
+	body := lyrics.LRC(song.Group, song.Title, lines, query.Enhanced)
+	c.Data(http.StatusOK, lyrics.FormatLRC.ContentType()+"; charset=utf-8", []byte(body))
+}
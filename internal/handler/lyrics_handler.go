@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/lyrics"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// maxLRCSize ограничивает размер загружаемого LRC
+const maxLRCSize = 1 << 20
+
+type LyricsHandler struct {
+	service *service.LyricsService
+	logger  *logrus.Logger
+}
+
+func NewLyricsHandler(s *service.LyricsService, log *logrus.Logger) *LyricsHandler {
+	return &LyricsHandler{
+		service: s,
+		logger:  log,
+	}
+}
+
+// @Summary Get structured lyrics
+// @Description Get lyrics split into stanzas with roles (intro, verse, pre-chorus, chorus, bridge, outro).
+// @Description Roles come from [Chorus]-style section markers; unmarked stanzas that repeat are detected as chorus.
+// @Tags lyrics
+// @Produce json
+// @Param id path string true "Song ID or public UUID"
+// @Param collapse query bool false "Replace repeated stanzas with a reference to their first occurrence"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Param If-Modified-Since header string false "Last-Modified from a previous response"
+// @Success 200 {object} StanzasResponse "Structured lyrics"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 404 {object} apperror.Problem "Song not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/{id}/lyrics/stanzas [get]
+func (h *LyricsHandler) GetStanzas(c *gin.Context) {
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var query StanzaQuery
+	if err := bindQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	song, stanzas, err := h.service.GetStanzas(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get song stanzas")
+		c.Error(err)
+		return
+	}
+	if query.Collapse {
+		stanzas = lyrics.Collapse(stanzas)
+	}
+
+	setLastModified(c, song.UpdatedAt)
+	c.JSON(http.StatusOK, newStanzasResponse(id, stanzas))
+}
+
+// @Summary Get synced lyrics
+// @Description Get per-line (and per-word) timestamps of the song lyrics
+// @Tags lyrics
+// @Produce json
+// @Param id path string true "Song ID or public UUID"
+// @Success 200 {object} TimingResponse "Synced lyrics"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 404 {object} apperror.Problem "Song not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/{id}/lyrics/timing [get]
+func (h *LyricsHandler) GetTiming(c *gin.Context) {
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	_, lines, err := h.service.GetTiming(c.Request.Context(), id)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get song timing")
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTimingResponse(id, lines))
+}
+
+// @Summary Set synced lyrics
+// @Description Replace song timestamps from JSON or from an LRC / enhanced LRC document. Timestamps must strictly increase. Send LRC as text/x-lrc or application/x-lrc. Timestamps are removed when the song text changes.
+// @Tags lyrics
+// @Accept json,text/x-lrc,application/x-lrc
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Song ID or public UUID"
+// @Param timing body TimingRequest true "Synced lines; alternatively send LRC with Content-Type text/x-lrc"
+// @Success 200 {object} TimingResponse "Stored synced lyrics"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the update permission"
+// @Failure 404 {object} apperror.Problem "Song not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/{id}/lyrics/timing [put]
+func (h *LyricsHandler) SetTiming(c *gin.Context) {
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var lines []lyrics.Line
+	if isLRCRequest(c) {
+		var body []byte
+		body, err = io.ReadAll(io.LimitReader(c.Request.Body, maxLRCSize+1))
+		if err != nil {
+			c.Error(apperror.Validation("invalid_body", "Failed to read request body", err))
+			return
+		}
+		if len(body) > maxLRCSize {
+			c.Error(apperror.Validation("invalid_lrc", "LRC document is too large", nil).
+				WithStatus(http.StatusRequestEntityTooLarge))
+			return
+		}
+		lines, err = h.service.ImportLRC(c.Request.Context(), id, string(body))
+	} else {
+		var req TimingRequest
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(err)
+			return
+		}
+		lines = req.toLines()
+		err = h.service.SetTiming(c.Request.Context(), id, lines)
+	}
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to set song timing")
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTimingResponse(id, lines))
+}
+
+// @Summary Delete synced lyrics
+// @Description Remove song timestamps
+// @Tags lyrics
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Song ID or public UUID"
+// @Success 204 "No Content"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the update permission"
+// @Failure 404 {object} apperror.Problem "Song or synced lyrics not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/{id}/lyrics/timing [delete]
+func (h *LyricsHandler) DeleteTiming(c *gin.Context) {
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.DeleteTiming(c.Request.Context(), id); err != nil {
+		h.logger.WithError(err).Error("Failed to delete song timing")
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get lyrics at playback position
+// @Description Get the current, previous and next line for playback position t (seconds)
+// @Tags lyrics
+// @Produce json
+// @Param id path string true "Song ID or public UUID"
+// @Param t query number true "Playback position in seconds"
+// @Success 200 {object} LyricsPositionResponse "Lines around the position"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 404 {object} apperror.Problem "Song or synced lyrics not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/{id}/lyrics/at [get]
+func (h *LyricsHandler) GetLineAt(c *gin.Context) {
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var query LyricsAtQuery
+	if err := bindQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	position, err := h.service.LineAt(c.Request.Context(), id, seconds(*query.T))
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get lyrics at position")
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newLyricsPositionResponse(*query.T, position))
+}
+
+// isLRCRequest определяет, что тело запроса передано в формате LRC.
+// Простой текст не считается LRC: в нём нет отметок времени.
+func isLRCRequest(c *gin.Context) bool {
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil {
+		return false
+	}
+	return mediaType == lyrics.FormatLRC.ContentType() || mediaType == "application/x-lrc"
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/lyrics"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRenderLyricsKeepsVary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	song := &entity.Song{Group: "Muse", Title: "Uprising"}
+	page := VerseListResponse{Items: []string{"Paranoia is in bloom"}}
+
+	for _, format := range []lyrics.Format{lyrics.FormatText, lyrics.FormatJSON} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		// Vary от authMiddleware должен сохраниться рядом с Accept
+		c.Writer.Header().Add("Vary", "Authorization, X-API-Key")
+		renderLyrics(c, format, song, page)
+
+		want := []string{"Authorization, X-API-Key", "Accept"}
+		if got := w.Header().Values("Vary"); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Vary = %q, want %q", format, got, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/musicinfo"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// MusicInfoHandler отдаёт песни библиотеки в формате Music Info API
+// (docs/openapi.yaml), чтобы другие экземпляры могли указать нас
+// в music_info_api и объединять курируемые библиотеки. Как и остальное
+// чтение, /info требует права read: при включённой авторизации такой
+// экземпляр передаёт ключ из music_info_api_key или api_key провайдера.
+type MusicInfoHandler struct {
+	service *service.SongService
+	logger  *logrus.Logger
+}
+
+var _ musicinfo.ServerInterface = (*MusicInfoHandler)(nil)
+
+func NewMusicInfoHandler(s *service.SongService, log *logrus.Logger) *MusicInfoHandler {
+	return &MusicInfoHandler{
+		service: s,
+		logger:  log,
+	}
+}
+
+// Router возвращает обработчик GET /info, сгенерированный по спецификации
+func (h *MusicInfoHandler) Router() http.Handler {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	musicinfo.RegisterHandlers(e, h)
+	return e
+}
+
+// GetSongInfo возвращает дату выпуска, текст и ссылку песни из базы.
+// Песня без какого-либо из этих полей считается ненайденной:
+// спецификация требует все три поля.
+func (h *MusicInfoHandler) GetSongInfo(ctx echo.Context, params musicinfo.GetSongInfoParams) error {
+	song, err := h.service.FindSong(ctx.Request().Context(), params.Group, params.Song)
+	if apperror.IsKind(err, apperror.KindNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Song not found")
+	}
+	if err != nil {
+		h.logger.WithFields(logrus.Fields{
+			"error": err,
+			"group": params.Group,
+			"song":  params.Song,
+		}).Error("Failed to get song info")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+
+	if song.ReleaseDate.IsZero() || song.Text == "" || song.Link == "" {
+		h.logger.WithFields(logrus.Fields{
+			"id":    song.ID,
+			"group": params.Group,
+			"song":  params.Song,
+		}).Info("Song info is incomplete")
+		return echo.NewHTTPError(http.StatusNotFound, "Song info is incomplete")
+	}
+
+	return ctx.JSON(http.StatusOK, musicinfo.SongDetail{
+		ReleaseDate: song.ReleaseDate.Format(musicinfo.ReleaseDateLayout),
+		Text:        song.Text,
+		Link:        song.Link,
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// Pagination — сведения о странице в ответе
+// @Description Pagination metadata
+type Pagination struct {
+	Page       int   `json:"page"`
+	Size       int   `json:"size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// newPagination вычисляет число страниц и отклоняет страницу за пределами
+// результата. Первая страница допустима и для пустого результата.
+func newPagination(query validation.PageQuery, total int64) (Pagination, error) {
+	p := Pagination{
+		Page:       query.Page,
+		Size:       query.Size,
+		Total:      total,
+		TotalPages: int((total + int64(query.Size) - 1) / int64(query.Size)),
+	}
+
+	if last := max(p.TotalPages, 1); p.Page > last {
+		return p, apperror.InvalidFields([]apperror.FieldError{{
+			Field:  "page",
+			Reason: fmt.Sprintf("must be at most %d", last),
+		}}, nil)
+	}
+	return p, nil
+}
+
+// setPageLinks выставляет заголовок Link (RFC 8288) со ссылками
+// first, prev, next и last на соседние страницы
+func setPageLinks(c *gin.Context, p Pagination) {
+	last := max(p.TotalPages, 1)
+
+	links := []string{pageLink(c, 1, "first")}
+	if p.Page > 1 {
+		links = append(links, pageLink(c, p.Page-1, "prev"))
+	}
+	if p.Page < last {
+		links = append(links, pageLink(c, p.Page+1, "next"))
+	}
+	links = append(links, pageLink(c, last, "last"))
+
+	// Add сохраняет ссылку successor-version, выставленную для устаревших маршрутов
+	c.Writer.Header().Add("Link", strings.Join(links, ", "))
+}
+
+func pageLink(c *gin.Context, page int, rel string) string {
+	u := *c.Request.URL
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	u.RawQuery = query.Encode()
+	return fmt.Sprintf("<%s>; rel=\"%s\"", u.RequestURI(), rel)
+}
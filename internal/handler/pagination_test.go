@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewPagination(t *testing.T) {
+	tests := []struct {
+		page, size int
+		total      int64
+		totalPages int
+		wantErr    bool
+	}{
+		{1, 10, 0, 0, false},
+		{1, 10, 1, 1, false},
+		{1, 10, 10, 1, false},
+		{2, 10, 11, 2, false},
+		{3, 5, 11, 3, false},
+		{2, 10, 0, 0, true},
+		{3, 10, 20, 2, true},
+	}
+	for _, tt := range tests {
+		p, err := newPagination(validation.PageQuery{Page: tt.page, Size: tt.size}, tt.total)
+		if p.TotalPages != tt.totalPages || p.Page != tt.page || p.Size != tt.size || p.Total != tt.total {
+			t.Errorf("newPagination(%d, %d, %d) = %+v, want total_pages %d", tt.page, tt.size, tt.total, p, tt.totalPages)
+		}
+		if tt.wantErr {
+			appErr, ok := apperror.As(err)
+			if !ok || appErr.Kind != apperror.KindValidation || len(appErr.Fields) != 1 || appErr.Fields[0].Field != "page" {
+				t.Errorf("newPagination(%d, %d, %d) error = %v, want invalid page", tt.page, tt.size, tt.total, err)
+			}
+		} else if err != nil {
+			t.Errorf("newPagination(%d, %d, %d) error = %v", tt.page, tt.size, tt.total, err)
+		}
+	}
+}
+
+func TestSetPageLinks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		target     string
+		page       int
+		totalPages int
+		want       []string
+	}{
+		{
+			name:   "единственная страница",
+			target: "/api/v1/songs", page: 1, totalPages: 1,
+			want: []string{
+				`</api/v1/songs?page=1>; rel="first"`,
+				`</api/v1/songs?page=1>; rel="last"`,
+			},
+		},
+		{
+			name:   "пустой результат",
+			target: "/api/v1/songs", page: 1, totalPages: 0,
+			want: []string{
+				`</api/v1/songs?page=1>; rel="first"`,
+				`</api/v1/songs?page=1>; rel="last"`,
+			},
+		},
+		{
+			name:   "средняя страница сохраняет остальные параметры",
+			target: "/api/v1/songs?group=Muse&page=2&size=5", page: 2, totalPages: 3,
+			want: []string{
+				`</api/v1/songs?group=Muse&page=1&size=5>; rel="first"`,
+				`</api/v1/songs?group=Muse&page=1&size=5>; rel="prev"`,
+				`</api/v1/songs?group=Muse&page=3&size=5>; rel="next"`,
+				`</api/v1/songs?group=Muse&page=3&size=5>; rel="last"`,
+			},
+		},
+		{
+			name:   "последняя страница",
+			target: "/songs/1/text?page=3", page: 3, totalPages: 3,
+			want: []string{
+				`</songs/1/text?page=1>; rel="first"`,
+				`</songs/1/text?page=2>; rel="prev"`,
+				`</songs/1/text?page=3>; rel="last"`,
+			},
+		},
+		{
+			name:   "экранирование параметров",
+			target: "/api/v1/songs?title=a+%26+b", page: 1, totalPages: 2,
+			want: []string{
+				`</api/v1/songs?page=1&title=a+%26+b>; rel="first"`,
+				`</api/v1/songs?page=2&title=a+%26+b>; rel="next"`,
+				`</api/v1/songs?page=2&title=a+%26+b>; rel="last"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+		setPageLinks(c, Pagination{Page: tt.page, Size: 10, TotalPages: tt.totalPages})
+
+		got := strings.Split(w.Header().Get("Link"), ", ")
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Link = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetPageLinksKeepsExistingLink(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/songs", nil)
+	successor := `</api/v1/songs>; rel="successor-version"`
+	c.Header("Link", successor)
+
+	setPageLinks(c, Pagination{Page: 1, Size: 10, TotalPages: 1})
+
+	links := w.Header().Values("Link")
+	if len(links) != 2 || links[0] != successor {
+		t.Errorf("Link = %q, want the successor-version link kept", links)
+	}
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ResyncHandler struct {
+	service *service.ResyncService
+	logger  *logrus.Logger
+}
+
+func NewResyncHandler(s *service.ResyncService, log *logrus.Logger) *ResyncHandler {
+	return &ResyncHandler{
+		service: s,
+		logger:  log,
+	}
+}
+
+// @Summary Re-sync song metadata
+// @Description Re-fetch info from the provider for songs older than max_age and report what changed
+// @Tags resync
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param group query string false "Filter by group"
+// @Param title query string false "Filter by title"
+// @Param max_age query string false "Minimum age since last sync, e.g. 24h"
+// @Success 200 {object} service.ResyncReport "Re-sync report"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the import permission"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/resync [post]
+func (h *ResyncHandler) Resync(c *gin.Context) {
+	var maxAge time.Duration
+	if value := c.Query("max_age"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			c.Error(apperror.Validation("invalid_max_age", "max_age must be a duration such as 24h", err))
+			return
+		}
+		maxAge = parsed
+	}
+
+	filter := make(map[string]string)
+	if group := c.Query("group"); group != "" {
+		filter["group"] = group
+	}
+	if title := c.Query("title"); title != "" {
+		filter["title"] = title
+	}
+
+	report, err := h.service.Resync(c.Request.Context(), filter, maxAge)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to resync songs")
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// @Summary Get queued song changes
+// @Description Get changes found by re-sync, filtered by status
+// @Tags resync
+// @Produce json
+// @Param status query string false "Change status" default(pending)
+// @Success 200 {array} entity.SongChange "List of changes"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/changes [get]
+func (h *ResyncHandler) GetChanges(c *gin.Context) {
+	status := c.DefaultQuery("status", entity.SongChangePending)
+
+	changes, err := h.service.GetChanges(c.Request.Context(), status)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to get song changes")
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// @Summary Apply queued song change
+// @Description Apply a pending change found by re-sync
+// @Tags resync
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Change ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the import permission"
+// @Failure 404 {object} apperror.Problem "Change not found"
+// @Failure 409 {object} apperror.Problem "Change is not pending, field is locked or field changed since the change was queued"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/changes/{id}/apply [post]
+func (h *ResyncHandler) ApplyChange(c *gin.Context) {
+	var param IDParam
+	if err := bindURI(c, &param); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.ApplyChange(c.Request.Context(), param.ID); err != nil {
+		h.logger.WithError(err).Error("Failed to apply song change")
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Reject queued song change
+// @Description Reject a pending change found by re-sync
+// @Tags resync
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Change ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the import permission"
+// @Failure 404 {object} apperror.Problem "Change not found"
+// @Failure 409 {object} apperror.Problem "Change is not pending or field is locked"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/changes/{id}/reject [post]
+func (h *ResyncHandler) RejectChange(c *gin.Context) {
+	var param IDParam
+	if err := bindURI(c, &param); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.RejectChange(c.Request.Context(), param.ID); err != nil {
+		h.logger.WithError(err).Error("Failed to reject song change")
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,23 +1,29 @@
 package handler
 
 import (
+	"context"
 	"net/http"
-	"strconv"
+	"slices"
+	"strings"
 
 	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/lyrics"
 	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/DusmatzodaQurbonli/song-library/internal/validation"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
 type SongHandler struct {
 	service *service.SongService
+	lyrics  *service.LyricsService
 	logger  *logrus.Logger
 }
 
-func NewSongHandler(s *service.SongService, log *logrus.Logger) *SongHandler {
+func NewSongHandler(s *service.SongService, lyrics *service.LyricsService, log *logrus.Logger) *SongHandler {
 	return &SongHandler{
 		service: s,
+		lyrics:  lyrics,
 		logger:  log,
 	}
 }
@@ -26,10 +32,18 @@ func NewSongHandler(s *service.SongService, log *logrus.Logger) *SongHandler {
 // @version 1.0
 // @description This is a simple song library API.
 // @host localhost:8080
-// @BasePath /
+// @BasePath /api/v1
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description API key or OIDC access token (JWT) as "Bearer <token>"
 
 // @Summary Get paginated songs
-// @Description Get songs with pagination
+// @Description Get songs with pagination. Lyrics text is omitted by default; request it with fields=text or include=lyrics.
+// @Description With ids= the listed songs are returned in the requested order as SongBatchResponse; pagination and filters are ignored.
 // @Tags songs
 // @Accept json
 // @Produce json
@@ -37,56 +51,260 @@ func NewSongHandler(s *service.SongService, log *logrus.Logger) *SongHandler {
 // @Param size query int false "Page size" default(10)
 // @Param group query string false "Filter by group"
 // @Param title query string false "Filter by title"
-// @Success 200 {array} entity.Song "List of songs"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Param fields query string false "Comma-separated fields to return: id, public_id, slug, group, title, release_date, text, link, synced_at, provenance, created_at, updated_at. id is always returned. Defaults to all fields except text"
+// @Param include query string false "Comma-separated related resources to embed: lyrics, stanzas, timing, artist"
+// @Param ids query string false "Comma-separated song IDs or public UUIDs to fetch, at most 100"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Success 200 {object} SongListResponse "Page of songs; Link header points to first/prev/next/last pages"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
 // @Router /songs [get]
 func (h *SongHandler) GetSongs(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+	var query SongListQuery
+	if err := bindQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
 
-	filter := make(map[string]string)
-	if group := c.Query("group"); group != "" {
-		filter["group"] = group
+	projection, err := newSongProjection(query.Fields, query.Include)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	if title := c.Query("title"); title != "" {
-		filter["title"] = title
+
+	if query.IDs != "" {
+		refs, err := parseSongIDs("ids", splitList(query.IDs), maxQueryIDs)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		h.getSongsByIDs(c, refs, projection)
+		return
 	}
 
-	songs, err := h.service.GetSongs(c.Request.Context(), filter, page, size)
+	songs, total, err := h.service.GetSongs(c.Request.Context(), query.filter(), service.SongOrder{}, projection.columns(), query.Page, query.Size)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get songs")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err})
+		c.Error(err)
 		return
 	}
+	pagination, err := newPagination(query.PageQuery, total)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Last-Modified для коллекции не выставляется: удаление песни или сдвиг
+	// строк между страницами не меняют последнее updated_at на странице,
+	// поэтому актуальность списка проверяется только по ETag
+	setPageLinks(c, pagination)
 
-	c.JSON(http.StatusOK, songs)
+	items, err := h.songItems(c.Request.Context(), songs, projection)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to embed song resources")
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SongListResponse{Items: items, Pagination: pagination})
 }
 
-// @Summary Get song text with pagination
-// @Description Get song text paginated by verses
+// @Summary Get songs by IDs
+// @Description Get songs by a list of IDs in one request. Each ID is a numeric song ID or a public UUID.
+// @Description Songs are returned in the requested order; IDs without a song are listed in missing.
 // @Tags songs
 // @Accept json
 // @Produce json
-// @Param id path int true "Song ID"
+// @Param batch body SongBatchRequest true "Song IDs, at most 1000"
+// @Param fields query string false "Comma-separated fields to return, see GET /songs"
+// @Param include query string false "Comma-separated related resources to embed: lyrics, stanzas, timing, artist"
+// @Success 200 {object} SongBatchResponse "Found songs and missing IDs"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/batch [post]
+func (h *SongHandler) GetSongsBatch(c *gin.Context) {
+	var req SongBatchRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+	var query SongProjectionQuery
+	if err := bindQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+	projection, err := newSongProjection(query.Fields, query.Include)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ids := make([]string, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		ids = append(ids, string(id))
+	}
+	refs, err := parseSongIDs("ids", ids, maxBatchIDs)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.getSongsByIDs(c, refs, projection)
+}
+
+func (h *SongHandler) getSongsByIDs(c *gin.Context, refs []service.SongRef, projection songProjection) {
+	songs, missing, err := h.service.GetSongsByIDs(c.Request.Context(), refs, projection.columns())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	items, err := h.songItems(c.Request.Context(), songs, projection)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to embed song resources")
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SongBatchResponse{Items: items, Missing: newSongIDs(missing)})
+}
+
+// @Summary Get song by slug
+// @Description Get a song by its human-readable slug. A previous slug of a renamed song redirects to the current one.
+// @Tags songs
+// @Produce json
+// @Param artist path string true "Artist part of the slug"
+// @Param title path string true "Title part of the slug"
+// @Success 200 {object} SongResponse "Song"
+// @Success 301 "Moved Permanently to the current slug"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 404 {object} apperror.Problem "Song not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/by-slug/{artist}/{title} [get]
+func (h *SongHandler) GetSongBySlug(c *gin.Context) {
+	var param SlugParam
+	if err := bindURI(c, &param); err != nil {
+		c.Error(err)
+		return
+	}
+
+	songSlug := param.Artist + "/" + param.Title
+	song, moved, err := h.service.GetSongBySlug(c.Request.Context(), songSlug)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if moved {
+		// путь до слага сохраняет версию API и префикс устаревших маршрутов
+		prefix := strings.TrimSuffix(c.Request.URL.Path, songSlug)
+		c.Redirect(http.StatusMovedPermanently, prefix+song.Slug)
+		return
+	}
+
+	setLastModified(c, song.UpdatedAt)
+	c.JSON(http.StatusOK, newSongResponse(song))
+}
+
+// songItems строит элементы списка и встраивает ресурсы из include=
+// одним запросом на ресурс для всей страницы
+func (h *SongHandler) songItems(ctx context.Context, songs []entity.Song, p songProjection) ([]SongItem, error) {
+	items := make([]SongItem, 0, len(songs))
+	for i := range songs {
+		items = append(items, newSongItem(&songs[i], p))
+	}
+
+	if p.includes(includeTiming) {
+		ids := make([]uint, 0, len(songs))
+		for _, song := range songs {
+			ids = append(ids, song.ID)
+		}
+		timings, err := h.lyrics.GetTimings(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			items[i].Timing = newTimingResponse(items[i].ID, timings[items[i].ID]).Lines
+		}
+	}
+
+	if p.includes(includeArtist) {
+		var names []string
+		for _, song := range songs {
+			if !slices.Contains(names, song.Group) {
+				names = append(names, song.Group)
+			}
+		}
+		counts, err := h.service.CountSongsByArtists(ctx, names)
+		if err != nil {
+			return nil, err
+		}
+		for i := range songs {
+			items[i].Artist = &ArtistResponse{Name: songs[i].Group, SongCount: counts[songs[i].Group]}
+		}
+	}
+
+	return items, nil
+}
+
+// @Summary Get song text with pagination
+// @Description Get song text paginated by verses as JSON, plain text, Markdown or HTML. LRC returns the whole synced lyrics and ignores pagination.
+// @Tags songs
+// @Accept json
+// @Produce json,plain,text/markdown,html,text/x-lrc
+// @Param id path string true "Song ID or public UUID"
 // @Param page query int false "Page number" default(1)
 // @Param size query int false "Page size" default(10)
-// @Success 200 {array} string "Paginated song text"
-// @Failure 404 {object} map[string]string "Song not found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Param If-None-Match header string false "ETag from a previous response"
+// @Param If-Modified-Since header string false "Last-Modified from a previous response"
+// @Param format query string false "Output format overriding Accept" Enums(json, text, markdown, html, lrc)
+// @Param enhanced query bool false "Include word timestamps in LRC output"
+// @Success 200 {object} VerseListResponse "Page of verses; Link header points to first/prev/next/last pages"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 404 {object} apperror.Problem "Song not found"
+// @Failure 406 {object} apperror.Problem "Requested format is not available"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
 // @Router /songs/{id}/text [get]
 func (h *SongHandler) GetSongText(c *gin.Context) {
-	id, _ := strconv.Atoi(c.Param("id"))
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var query validation.PageQuery
+	if err := bindQuery(c, &query); err != nil {
+		c.Error(err)
+		return
+	}
+	format, err := negotiateLyricsFormat(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if format == lyrics.FormatLRC {
+		h.renderLRC(c, id)
+		return
+	}
 
-	verses, err := h.service.GetSongText(c.Request.Context(), uint(id), page, size)
+	song, verses, total, err := h.service.GetSongText(c.Request.Context(), id, query.Page, query.Size)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get song text")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+	pagination, err := newPagination(query, int64(total))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, verses)
+	setLastModified(c, song.UpdatedAt)
+	setPageLinks(c, pagination)
+	renderLyrics(c, format, song, VerseListResponse{Items: verses, Pagination: pagination})
 }
 
 // @Summary Add new song
@@ -94,76 +312,146 @@ func (h *SongHandler) GetSongText(c *gin.Context) {
 // @Tags songs
 // @Accept json
 // @Produce json
-// @Param song body entity.Song true "Song Data"
-// @Success 201 {object} entity.Song "Created song"
-// @Failure 400 {object} map[string]string "Invalid input"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param song body validation.AddSongRequest true "Song Data"
+// @Success 201 {object} SongResponse "Created song"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the create permission"
+// @Failure 404 {object} apperror.Problem "Song not found at provider (code provider_not_found)"
+// @Failure 422 {object} apperror.Problem "Invalid provider payload (code provider_invalid_payload)"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error, or provider rejected the request (code provider_bad_request)"
+// @Failure 502 {object} apperror.Problem "Provider unavailable (code provider_unavailable)"
+// @Failure 503 {object} apperror.Problem "Provider rate limited (code provider_rate_limited)"
+// @Failure 504 {object} apperror.Problem "Provider timeout (code provider_timeout)"
 // @Router /songs [post]
 func (h *SongHandler) AddSong(c *gin.Context) {
-	var song entity.Song
-	if err := c.ShouldBindJSON(&song); err != nil {
+	var req validation.AddSongRequest
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.WithError(err).Error("Failed to bind JSON")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	createdSong, err := h.service.AddSong(c.Request.Context(), &song)
+	createdSong, err := h.service.AddSong(c.Request.Context(), req.Song())
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to add song")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, createdSong)
+	c.JSON(http.StatusCreated, newSongResponse(createdSong))
 }
 
 // @Summary Update song
-// @Description Update song data
+// @Description Update song data. Changing the text removes the song lyric timestamps.
 // @Tags songs
 // @Accept json
 // @Produce json
-// @Param id path int true "Song ID"
-// @Param song body entity.Song true "Song Data"
-// @Success 200 {object} entity.Song "Updated song"
-// @Failure 400 {object} map[string]string "Invalid input"
-// @Failure 404 {object} map[string]string "Song not found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Song ID or public UUID"
+// @Param song body validation.UpdateSongRequest true "Song Data"
+// @Success 200 {object} SongResponse "Updated song"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the update permission"
+// @Failure 404 {object} apperror.Problem "Song not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
 // @Router /songs/{id} [put]
 func (h *SongHandler) UpdateSong(c *gin.Context) {
-	id, _ := strconv.Atoi(c.Param("id"))
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	var song entity.Song
-	if err := c.ShouldBindJSON(&song); err != nil {
+	var req validation.UpdateSongRequest
+	if err := bindJSON(c, &req); err != nil {
 		h.logger.WithError(err).Error("Failed to bind JSON")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	song.ID = uint(id)
-	if err := h.service.UpdateSong(c.Request.Context(), &song); err != nil {
+	song := req.Song(id)
+	if err := h.service.UpdateSong(c.Request.Context(), song); err != nil {
 		h.logger.WithError(err).Error("Failed to update song")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newSongResponse(song))
+}
+
+// @Summary Set song field locks
+// @Description Lock or unlock fields (release_date, text, link) against provider overwrites
+// @Tags songs
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Song ID or public UUID"
+// @Param locks body map[string]bool true "Lock flags by field"
+// @Success 200 {object} SongResponse "Updated song"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the update permission"
+// @Failure 404 {object} apperror.Problem "Song not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
+// @Router /songs/{id}/locks [put]
+func (h *SongHandler) SetFieldLocks(c *gin.Context) {
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var locks map[string]bool
+	if err := bindJSON(c, &locks); err != nil {
+		h.logger.WithError(err).Error("Failed to bind JSON")
+		c.Error(err)
+		return
+	}
+
+	song, err := h.service.SetFieldLocks(c.Request.Context(), id, locks)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to set field locks")
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, song)
+	c.JSON(http.StatusOK, newSongResponse(song))
 }
 
 // @Summary Delete song
 // @Description Delete a song by ID
 // @Tags songs
 // @Produce json
-// @Param id path int true "Song ID"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Song ID or public UUID"
 // @Success 204 "No Content"
-// @Failure 404 {object} map[string]string "Song not found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Failure 400 {object} apperror.Problem "Invalid input"
+// @Failure 401 {object} apperror.Problem "Missing or invalid credentials"
+// @Failure 403 {object} apperror.Problem "Role lacks the delete permission"
+// @Failure 404 {object} apperror.Problem "Song not found"
+// @Failure 429 {object} apperror.Problem "Rate limit exceeded"
+// @Failure 500 {object} apperror.Problem "Internal Server Error"
 // @Router /songs/{id} [delete]
 func (h *SongHandler) DeleteSong(c *gin.Context) {
-	id, _ := strconv.Atoi(c.Param("id"))
+	id, err := bindSongID(c, h.service)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	if err := h.service.DeleteSong(c.Request.Context(), uint(id)); err != nil {
+	if err := h.service.DeleteSong(c.Request.Context(), id); err != nil {
 		h.logger.WithError(err).Error("Failed to delete song")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
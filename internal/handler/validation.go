@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// bindJSON разбирает и проверяет тело запроса
+func bindJSON(c *gin.Context, dst any) error {
+	return validation.Error(c.ShouldBindJSON(dst))
+}
+
+// bindQuery разбирает и проверяет параметры строки запроса
+func bindQuery(c *gin.Context, dst any) error {
+	if fields := checkNumbers(dst, "form", c.Query); len(fields) > 0 {
+		return apperror.InvalidFields(fields, nil)
+	}
+	return validation.Error(c.ShouldBindQuery(dst))
+}
+
+// bindURI разбирает и проверяет параметры пути
+func bindURI(c *gin.Context, dst any) error {
+	if fields := checkNumbers(dst, "uri", c.Param); len(fields) > 0 {
+		return apperror.InvalidFields(fields, nil)
+	}
+	return validation.Error(c.ShouldBindUri(dst))
+}
+
+// checkNumbers проверяет числовые параметры до привязки:
+// gin сообщает об ошибке разбора без имени параметра
+func checkNumbers(dst any, tag string, lookup func(string) string) []apperror.FieldError {
+	var fields []apperror.FieldError
+
+	t := reflect.TypeOf(dst)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for _, f := range reflect.VisibleFields(t) {
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name == "" {
+			continue
+		}
+		value := lookup(name)
+		if value == "" {
+			continue
+		}
+
+		kind := f.Type.Kind()
+		if kind == reflect.Pointer {
+			kind = f.Type.Elem().Kind()
+		}
+		switch kind {
+		case reflect.Int, reflect.Int64, reflect.Int32:
+			if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+				fields = append(fields, apperror.FieldError{Field: name, Reason: "must be an integer"})
+			}
+		case reflect.Uint, reflect.Uint64, reflect.Uint32:
+			if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+				fields = append(fields, apperror.FieldError{Field: name, Reason: "must be a positive integer"})
+			}
+		case reflect.Float64, reflect.Float32:
+			if v, err := strconv.ParseFloat(value, 64); err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+				fields = append(fields, apperror.FieldError{Field: name, Reason: "must be a number"})
+			}
+		case reflect.Bool:
+			if _, err := strconv.ParseBool(value); err != nil {
+				fields = append(fields, apperror.FieldError{Field: name, Reason: "must be a boolean"})
+			}
+		}
+	}
+
+	return fields
+}
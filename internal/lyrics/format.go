@@ -0,0 +1,110 @@
+// Package lyrics содержит представления текста песни для разных клиентов.
+package lyrics
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// Format — формат представления текста песни
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatLRC      Format = "lrc"
+)
+
+// Formats перечисляет форматы в порядке предпочтения при согласовании
+var Formats = []Format{FormatJSON, FormatText, FormatMarkdown, FormatHTML, FormatLRC}
+
+var contentTypes = map[Format]string{
+	FormatJSON:     "application/json",
+	FormatText:     "text/plain",
+	FormatMarkdown: "text/markdown",
+	FormatHTML:     "text/html",
+	FormatLRC:      "text/x-lrc",
+}
+
+// ContentType возвращает MIME-тип формата
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// ParseFormat возвращает формат по имени из параметра format=
+func ParseFormat(name string) (Format, bool) {
+	f := Format(strings.ToLower(name))
+	_, ok := contentTypes[f]
+	return f, ok
+}
+
+// FormatByContentType возвращает формат по MIME-типу
+func FormatByContentType(contentType string) (Format, bool) {
+	for f, ct := range contentTypes {
+		if ct == contentType {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+func stanzaLines(stanza string) []string {
+	return strings.Split(strings.TrimSpace(stanza), "\n")
+}
+
+// Text собирает куплеты в простой текст, разделяя их пустой строкой
+func Text(stanzas []string) string {
+	trimmed := make([]string, 0, len(stanzas))
+	for _, stanza := range stanzas {
+		trimmed = append(trimmed, strings.TrimSpace(stanza))
+	}
+	return strings.Join(trimmed, "\n\n") + "\n"
+}
+
+// Markdown собирает куплеты в Markdown с заголовком песни.
+// Строки куплета разделяются жёстким переносом.
+func Markdown(group, title string, stanzas []string) string {
+	var b strings.Builder
+	b.WriteString("# " + escapeMarkdown(title) + "\n\n")
+	b.WriteString("*" + escapeMarkdown(group) + "*\n")
+
+	for _, stanza := range stanzas {
+		lines := stanzaLines(stanza)
+		for i, line := range lines {
+			lines[i] = escapeMarkdown(line)
+		}
+		b.WriteString("\n" + strings.Join(lines, "\\\n") + "\n")
+	}
+	return b.String()
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "#", `\#`,
+	"[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// HTML собирает куплеты во фрагмент HTML с разметкой строф.
+// Весь текст экранируется, поэтому фрагмент безопасно встраивать в страницу.
+func HTML(stanzas []string) string {
+	var b strings.Builder
+	b.WriteString(`<div class="lyrics">` + "\n")
+	for i, stanza := range stanzas {
+		b.WriteString(`<p class="stanza" data-index="` + strconv.Itoa(i+1) + `">`)
+		for j, line := range stanzaLines(stanza) {
+			if j > 0 {
+				b.WriteString("<br>\n")
+			}
+			b.WriteString(`<span class="line">` + html.EscapeString(line) + `</span>`)
+		}
+		b.WriteString("</p>\n")
+	}
+	b.WriteString("</div>\n")
+	return b.String()
+}
@@ -0,0 +1,216 @@
+package lyrics
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	lrcTimeTag = regexp.MustCompile(`^\[(\d+):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	lrcMetaTag = regexp.MustCompile(`^\[([a-zA-Z#]+):(.*)\]$`)
+	lrcWordTag = regexp.MustCompile(`<(\d+):(\d{1,2})(?:[.:](\d{1,3}))?>`)
+)
+
+// ParseError описывает ошибку разбора LRC с номером строки исходного текста
+type ParseError struct {
+	Line   int
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("lrc line %d: %s", e.Line, e.Reason)
+}
+
+// ParseLRC разбирает LRC и enhanced LRC. Строки с несколькими отметками
+// времени разворачиваются, тег [offset:] применяется ко всем отметкам.
+// Порядок отметок не исправляется — его проверяет Validate.
+func ParseLRC(src string) ([]Line, error) {
+	var (
+		lines    []Line
+		offset   time.Duration
+		repeated bool
+	)
+
+	for n, raw := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		if m := lrcMetaTag.FindStringSubmatch(raw); m != nil && !lrcTimeTag.MatchString(raw) {
+			if strings.EqualFold(m[1], "offset") {
+				ms, err := strconv.Atoi(strings.TrimSpace(m[2]))
+				if err != nil {
+					return nil, &ParseError{Line: n + 1, Reason: "offset must be an integer number of milliseconds"}
+				}
+				offset = time.Duration(ms) * time.Millisecond
+			}
+			continue
+		}
+
+		var starts []time.Duration
+		rest := raw
+		for {
+			m := lrcTimeTag.FindStringSubmatch(rest)
+			if m == nil {
+				break
+			}
+			start, err := parseTimestamp(m[1], m[2], m[3])
+			if err != nil {
+				return nil, &ParseError{Line: n + 1, Reason: err.Error()}
+			}
+			starts = append(starts, start)
+			rest = rest[len(m[0]):]
+		}
+		if len(starts) == 0 {
+			return nil, &ParseError{Line: n + 1, Reason: "missing [mm:ss.xx] timestamp"}
+		}
+		repeated = repeated || len(starts) > 1
+
+		text, words, err := parseWords(rest)
+		if err != nil {
+			return nil, &ParseError{Line: n + 1, Reason: err.Error()}
+		}
+		for _, start := range starts {
+			lines = append(lines, Line{Start: start, Text: text, Words: shiftWords(words, start)})
+		}
+	}
+
+	// сжатый LRC перечисляет повторы одной строкой, поэтому порядок восстанавливается
+	if repeated {
+		sort.SliceStable(lines, func(i, j int) bool {
+			return lines[i].Start < lines[j].Start
+		})
+	}
+
+	if offset != 0 {
+		for i := range lines {
+			lines[i].Start = applyOffset(lines[i].Start, offset)
+			for j := range lines[i].Words {
+				lines[i].Words[j].Start = applyOffset(lines[i].Words[j].Start, offset)
+			}
+		}
+	}
+
+	return lines, nil
+}
+
+// parseWords выделяет из текста строки слова с отметками <mm:ss.xx>.
+// Текст до первой отметки считается словом без собственного времени.
+func parseWords(s string) (string, []Word, error) {
+	matches := lrcWordTag.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return strings.TrimSpace(s), nil, nil
+	}
+
+	var (
+		words []Word
+		text  strings.Builder
+	)
+	if prefix := s[:matches[0][0]]; strings.TrimSpace(prefix) != "" {
+		words = append(words, Word{Start: -1, Text: strings.TrimSpace(prefix)})
+		text.WriteString(prefix)
+	}
+	for i, m := range matches {
+		start, err := parseTimestamp(s[m[2]:m[3]], s[m[4]:m[5]], submatch(s, m[6], m[7]))
+		if err != nil {
+			return "", nil, err
+		}
+		end := len(s)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		segment := s[m[1]:end]
+		text.WriteString(segment)
+		if word := strings.TrimSpace(segment); word != "" {
+			words = append(words, Word{Start: start, Text: word})
+		}
+	}
+
+	return strings.TrimSpace(text.String()), words, nil
+}
+
+// shiftWords привязывает слово без отметки к началу строки
+func shiftWords(words []Word, start time.Duration) []Word {
+	if words == nil {
+		return nil
+	}
+	shifted := make([]Word, len(words))
+	copy(shifted, words)
+	for i := range shifted {
+		if shifted[i].Start < 0 {
+			shifted[i].Start = start
+		}
+	}
+	return shifted
+}
+
+func submatch(s string, from, to int) string {
+	if from < 0 {
+		return ""
+	}
+	return s[from:to]
+}
+
+func parseTimestamp(minutes, seconds, fraction string) (time.Duration, error) {
+	m, err := strconv.Atoi(minutes)
+	if err != nil {
+		return 0, fmt.Errorf("invalid minutes %q", minutes)
+	}
+	sec, err := strconv.Atoi(seconds)
+	if err != nil || sec >= 60 {
+		return 0, fmt.Errorf("invalid seconds %q", seconds)
+	}
+	ms := 0
+	if fraction != "" {
+		// .5 — десятые, .50 — сотые, .500 — миллисекунды
+		ms, _ = strconv.Atoi((fraction + "00")[:3])
+	}
+	return time.Duration(m)*time.Minute + time.Duration(sec)*time.Second + time.Duration(ms)*time.Millisecond, nil
+}
+
+// applyOffset сдвигает отметку: положительный offset по спецификации LRC
+// означает, что текст появляется раньше
+func applyOffset(t, offset time.Duration) time.Duration {
+	if t -= offset; t < 0 {
+		return 0
+	}
+	return t
+}
+
+// LRC собирает строки в формат LRC с тегами исполнителя и названия.
+// При enhanced слова выводятся с отметками <mm:ss.xx>.
+func LRC(group, title string, lines []Line, enhanced bool) string {
+	var b strings.Builder
+	if group != "" {
+		b.WriteString("[ar:" + group + "]\n")
+	}
+	if title != "" {
+		b.WriteString("[ti:" + title + "]\n")
+	}
+
+	for _, line := range lines {
+		b.WriteString("[" + formatTimestamp(line.Start) + "]")
+		if !enhanced || len(line.Words) == 0 {
+			b.WriteString(line.Text + "\n")
+			continue
+		}
+		for i, word := range line.Words {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString("<" + formatTimestamp(word.Start) + ">" + word.Text)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func formatTimestamp(t time.Duration) string {
+	cs := t.Milliseconds() / 10
+	return fmt.Sprintf("%02d:%02d.%02d", cs/6000, cs/100%60, cs%100)
+}
@@ -0,0 +1,162 @@
+package lyrics
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func ms(n int64) time.Duration {
+	return time.Duration(n) * time.Millisecond
+}
+
+func TestParseLRC(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []Line
+	}{
+		{
+			name: "метаданные и строки",
+			src:  "[ar:Queen]\n[ti:Innuendo]\n\n[00:01.50]While the sun hangs in the sky\n[00:05.00]And the desert has sand\n",
+			want: []Line{
+				{Start: ms(1500), Text: "While the sun hangs in the sky"},
+				{Start: ms(5000), Text: "And the desert has sand"},
+			},
+		},
+		{
+			name: "десятые, сотые и миллисекунды",
+			src:  "[00:01.5]a\n[00:02.05]b\n[00:03.125]c\n[00:04]d\n[01:02:50]e",
+			want: []Line{
+				{Start: ms(1500), Text: "a"},
+				{Start: ms(2050), Text: "b"},
+				{Start: ms(3125), Text: "c"},
+				{Start: ms(4000), Text: "d"},
+				{Start: ms(62500), Text: "e"},
+			},
+		},
+		{
+			name: "повторы разворачиваются по времени",
+			src:  "[00:10.00][00:30.00]Chorus\n[00:20.00]Verse",
+			want: []Line{
+				{Start: ms(10000), Text: "Chorus"},
+				{Start: ms(20000), Text: "Verse"},
+				{Start: ms(30000), Text: "Chorus"},
+			},
+		},
+		{
+			name: "offset сдвигает отметки раньше и не уходит в минус",
+			src:  "[offset:500]\n[00:00.20]a\n[00:02.00]b <00:02.00>c <00:02.60>d",
+			want: []Line{
+				{Start: 0, Text: "a"},
+				{Start: ms(1500), Text: "b c d", Words: []Word{
+					{Start: ms(1500), Text: "b"},
+					{Start: ms(1500), Text: "c"},
+					{Start: ms(2100), Text: "d"},
+				}},
+			},
+		},
+		{
+			name: "enhanced LRC",
+			src:  "[00:01.00]<00:01.00>Hello <00:01.40>world",
+			want: []Line{
+				{Start: ms(1000), Text: "Hello world", Words: []Word{
+					{Start: ms(1000), Text: "Hello"},
+					{Start: ms(1400), Text: "world"},
+				}},
+			},
+		},
+		{
+			name: "CRLF и пустая строка с отметкой",
+			src:  "[00:01.00]a\r\n[00:02.00]\r\n",
+			want: []Line{
+				{Start: ms(1000), Text: "a"},
+				{Start: ms(2000), Text: ""},
+			},
+		},
+		{
+			name: "без строк",
+			src:  "[ar:Queen]\n\n",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseLRC(tt.src)
+		if err != nil {
+			t.Errorf("%s: ParseLRC() error = %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ParseLRC() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseLRCErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		line int
+	}{
+		{"простой текст", "While the sun hangs in the sky", 1},
+		{"строка без отметки", "[00:01.00]a\nb", 2},
+		{"секунды вне диапазона", "[00:61.00]a", 1},
+		{"offset не число", "[offset:soon]\n[00:01.00]a", 1},
+		{"слово с неверной отметкой", "[ar:Queen]\n[00:01.00]<00:99.00>a", 2},
+	}
+	for _, tt := range tests {
+		_, err := ParseLRC(tt.src)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%s: ParseLRC() error = %v, want *ParseError", tt.name, err)
+			continue
+		}
+		if parseErr.Line != tt.line {
+			t.Errorf("%s: ParseLRC() error line = %d, want %d", tt.name, parseErr.Line, tt.line)
+		}
+	}
+}
+
+func TestLRC(t *testing.T) {
+	lines := []Line{
+		{Start: ms(1500), Text: "Hello world", Words: []Word{
+			{Start: ms(1500), Text: "Hello"},
+			{Start: ms(1900), Text: "world"},
+		}},
+		{Start: ms(61234), Text: "Again"},
+	}
+	tests := []struct {
+		name         string
+		group, title string
+		enhanced     bool
+		want         string
+	}{
+		{"простой", "Queen", "Innuendo", false,
+			"[ar:Queen]\n[ti:Innuendo]\n[00:01.50]Hello world\n[01:01.23]Again\n"},
+		{"enhanced", "", "", true,
+			"[00:01.50]<00:01.50>Hello <00:01.90>world\n[01:01.23]Again\n"},
+	}
+	for _, tt := range tests {
+		if got := LRC(tt.group, tt.title, lines, tt.enhanced); got != tt.want {
+			t.Errorf("%s: LRC() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLRCRoundTrip(t *testing.T) {
+	want := []Line{
+		{Start: ms(1500), Text: "Hello world", Words: []Word{
+			{Start: ms(1500), Text: "Hello"},
+			{Start: ms(1900), Text: "world"},
+		}},
+		{Start: ms(3000), Text: "Again"},
+	}
+	got, err := ParseLRC(LRC("Queen", "Innuendo", want, true))
+	if err != nil {
+		t.Fatalf("ParseLRC(LRC()) error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLRC(LRC()) = %+v, want %+v", got, want)
+	}
+}
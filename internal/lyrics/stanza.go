@@ -0,0 +1,212 @@
+package lyrics
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Role — роль строфы в структуре песни
+type Role string
+
+const (
+	RoleIntro     Role = "intro"
+	RoleVerse     Role = "verse"
+	RolePreChorus Role = "pre-chorus"
+	RoleChorus    Role = "chorus"
+	RoleBridge    Role = "bridge"
+	RoleOutro     Role = "outro"
+)
+
+// Stanza — строфа текста. Number нумерует различные строфы одной роли,
+// RepeatOf указывает индекс первого вхождения повторённой строфы.
+type Stanza struct {
+	Role     Role
+	Number   int
+	Label    string
+	Lines    []string
+	RepeatOf *int
+}
+
+// sectionMarker распознаёт разметку вида [Chorus], [Verse 2] или [Bridge: Artist]
+var sectionMarker = regexp.MustCompile(`^\[\s*([^\]:]+?)\s*(\d+)?\s*(?::[^\]]*)?\]$`)
+
+var roleAliases = map[string]Role{
+	"intro":      RoleIntro,
+	"verse":      RoleVerse,
+	"pre-chorus": RolePreChorus,
+	"prechorus":  RolePreChorus,
+	"pre chorus": RolePreChorus,
+	"chorus":     RoleChorus,
+	"refrain":    RoleChorus,
+	"hook":       RoleChorus,
+	"bridge":     RoleBridge,
+	"outro":      RoleOutro,
+}
+
+type section struct {
+	role   Role
+	marked bool
+	label  string
+	lines  []string
+}
+
+// ParseStanzas разбирает текст на строфы. Строфы разделяются пустой строкой
+// или разметкой [Section]. Роль берётся из разметки, а неразмеченная строфа,
+// встречающаяся в тексте повторно, считается припевом. Размеченная строфа,
+// повторяющая текст предыдущей, сохраняет свою роль и ссылается на неё.
+func ParseStanzas(text string) []Stanza {
+	sections := splitSections(text)
+
+	keys := make([]string, len(sections))
+	counts := make(map[string]int)
+	for i, sec := range sections {
+		keys[i] = stanzaKey(sec.lines)
+		if keys[i] != "" {
+			counts[keys[i]]++
+		}
+	}
+
+	var (
+		stanzas = make([]Stanza, 0, len(sections))
+		first   = make(map[string]int)
+		byLabel = make(map[string]int)
+		numbers = make(map[Role]int)
+	)
+	for i, sec := range sections {
+		// пустая разметка вроде [Chorus] повторяет ранее встреченную строфу
+		if len(sec.lines) == 0 {
+			if j, ok := byLabel[sec.label]; ok {
+				stanzas = append(stanzas, repeatOf(stanzas, j))
+			}
+			continue
+		}
+
+		if j, ok := first[keys[i]]; ok {
+			repeat := repeatOf(stanzas, j)
+			repeat.Lines = sec.lines
+			if sec.marked {
+				repeat.Label = sec.label
+				// разметка вроде [Outro] важнее роли первого вхождения
+				if sec.role != repeat.Role {
+					numbers[sec.role]++
+					repeat.Role, repeat.Number = sec.role, numbers[sec.role]
+				}
+			}
+			stanzas = append(stanzas, repeat)
+			continue
+		}
+
+		role := sec.role
+		if !sec.marked && counts[keys[i]] > 1 {
+			role = RoleChorus
+		}
+		numbers[role]++
+
+		first[keys[i]] = len(stanzas)
+		if sec.marked {
+			byLabel[sec.label] = len(stanzas)
+		}
+		if _, ok := byLabel[string(role)]; !ok {
+			byLabel[string(role)] = len(stanzas)
+		}
+		stanzas = append(stanzas, Stanza{
+			Role:   role,
+			Number: numbers[role],
+			Label:  sec.label,
+			Lines:  sec.lines,
+		})
+	}
+
+	return stanzas
+}
+
+// Collapse убирает строки повторённых строф, оставляя ссылку на первое вхождение
+func Collapse(stanzas []Stanza) []Stanza {
+	collapsed := make([]Stanza, len(stanzas))
+	for i, stanza := range stanzas {
+		if stanza.RepeatOf != nil {
+			stanza.Lines = nil
+		}
+		collapsed[i] = stanza
+	}
+	return collapsed
+}
+
+func repeatOf(stanzas []Stanza, i int) Stanza {
+	origin := stanzas[i]
+	index := i
+	return Stanza{
+		Role:     origin.Role,
+		Number:   origin.Number,
+		Label:    origin.Label,
+		Lines:    origin.Lines,
+		RepeatOf: &index,
+	}
+}
+
+func splitSections(text string) []section {
+	var (
+		sections []section
+		current  section
+	)
+	flush := func() {
+		if len(current.lines) > 0 || current.marked {
+			sections = append(sections, current)
+		}
+		current = section{role: RoleVerse}
+	}
+	current.role = RoleVerse
+
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			if len(current.lines) > 0 {
+				flush()
+			}
+			continue
+		}
+		if role, label, ok := parseMarker(line); ok {
+			flush()
+			current = section{role: role, marked: true, label: label}
+			continue
+		}
+		current.lines = append(current.lines, line)
+	}
+	flush()
+
+	return sections
+}
+
+// parseMarker возвращает роль и нормализованную метку разметки строфы
+func parseMarker(line string) (Role, string, bool) {
+	m := sectionMarker.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", false
+	}
+	role, ok := roleAliases[strings.ToLower(m[1])]
+	if !ok {
+		return "", "", false
+	}
+	label := string(role)
+	if m[2] != "" {
+		n, _ := strconv.Atoi(m[2])
+		label += " " + strconv.Itoa(n)
+	}
+	return role, label, true
+}
+
+// stanzaKey нормализует строфу для сравнения: регистр, пробелы и пунктуация не учитываются
+func stanzaKey(lines []string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		for _, r := range strings.ToLower(line) {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				b.WriteRune(r)
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,141 @@
+package lyrics
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// describe кратко записывает строфы: роль, номер, метку, число строк
+// и индекс первого вхождения для повторов
+func describe(stanzas []Stanza) []string {
+	out := make([]string, 0, len(stanzas))
+	for _, s := range stanzas {
+		d := fmt.Sprintf("%s %d %q %d", s.Role, s.Number, s.Label, len(s.Lines))
+		if s.RepeatOf != nil {
+			d += fmt.Sprintf(" =%d", *s.RepeatOf)
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func TestParseStanzas(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "пустой текст",
+			text: "\n\n",
+			want: []string{},
+		},
+		{
+			name: "куплеты без повторов",
+			text: "First line\nSecond line\n\nThird line",
+			want: []string{`verse 1 "" 2`, `verse 2 "" 1`},
+		},
+		{
+			name: "повтор без разметки становится припевом",
+			text: "Verse one\n\nChorus line\nAgain\n\nVerse two\n\nChorus line\nAgain",
+			want: []string{`verse 1 "" 1`, `chorus 1 "" 2`, `verse 2 "" 1`, `chorus 1 "" 2 =1`},
+		},
+		{
+			name: "регистр, пробелы и пунктуация не мешают повтору",
+			text: "Ooh, baby!\nDon't you know\n\nVerse\n\nooh baby\n  dont you know...",
+			want: []string{`chorus 1 "" 2`, `verse 1 "" 1`, `chorus 1 "" 2 =0`},
+		},
+		{
+			name: "разметка задаёт роли и номера",
+			text: "[Intro]\nHey\n\n[Verse 1]\nLine a\n[Pre-Chorus]\nRising\n[Chorus]\nSing it\n[Verse 2]\nLine b\n[Bridge: Guest]\nSlow\n[Outro]\nBye",
+			want: []string{
+				`intro 1 "intro" 1`, `verse 1 "verse 1" 1`, `pre-chorus 1 "pre-chorus" 1`,
+				`chorus 1 "chorus" 1`, `verse 2 "verse 2" 1`, `bridge 1 "bridge" 1`, `outro 1 "outro" 1`,
+			},
+		},
+		{
+			name: "пустая разметка повторяет строфу с той же меткой",
+			text: "[Chorus]\nSing it\n\n[Verse]\nLine\n\n[Chorus]\n\n[Hook]",
+			want: []string{`chorus 1 "chorus" 1`, `verse 1 "verse" 1`, `chorus 1 "chorus" 1 =0`, `chorus 1 "chorus" 1 =0`},
+		},
+		{
+			name: "разметка повтора сохраняет свою роль",
+			text: "[Chorus]\nSing it\n\n[Verse]\nLine\n\n[Outro]\nSing it",
+			want: []string{`chorus 1 "chorus" 1`, `verse 1 "verse" 1`, `outro 1 "outro" 1 =0`},
+		},
+		{
+			name: "размеченный повтор неразмеченного припева",
+			text: "Sing it\n\n[Verse]\nLine\n\n[Outro]\nSing it\n\n[Outro]\nBye",
+			want: []string{`chorus 1 "" 1`, `verse 1 "verse" 1`, `outro 1 "outro" 1 =0`, `outro 2 "outro" 1`},
+		},
+		{
+			name: "повтор с той же ролью сохраняет номер",
+			text: "[Chorus]\nSing it\n\n[Chorus 2]\nSing it",
+			want: []string{`chorus 1 "chorus" 1`, `chorus 1 "chorus 2" 1 =0`},
+		},
+		{
+			name: "пустая разметка без исходной строфы пропускается",
+			text: "[Bridge]\n[Verse]\nLine",
+			want: []string{`verse 1 "verse" 1`},
+		},
+		{
+			name: "пустая строка после разметки не отделяет строфу",
+			text: "[Bridge]\n\nLine",
+			want: []string{`bridge 1 "bridge" 1`},
+		},
+		{
+			name: "неизвестная разметка остаётся строкой текста",
+			text: "[Guitar solo]\nLine",
+			want: []string{`verse 1 "" 2`},
+		},
+		{
+			name: "CRLF",
+			text: "Line a\r\n\r\nLine b\r\n",
+			want: []string{`verse 1 "" 1`, `verse 2 "" 1`},
+		},
+	}
+	for _, tt := range tests {
+		if got := describe(ParseStanzas(tt.text)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ParseStanzas() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseMarker(t *testing.T) {
+	tests := []struct {
+		line  string
+		role  Role
+		label string
+		ok    bool
+	}{
+		{"[Chorus]", RoleChorus, "chorus", true},
+		{"[ verse 02 ]", RoleVerse, "verse 2", true},
+		{"[Pre chorus]", RolePreChorus, "pre-chorus", true},
+		{"[Refrain: Freddie]", RoleChorus, "chorus", true},
+		{"[Solo]", "", "", false},
+		{"Chorus", "", "", false},
+		{"[Chorus] la la", "", "", false},
+	}
+	for _, tt := range tests {
+		role, label, ok := parseMarker(tt.line)
+		if role != tt.role || label != tt.label || ok != tt.ok {
+			t.Errorf("parseMarker(%q) = %q, %q, %v, want %q, %q, %v", tt.line, role, label, ok, tt.role, tt.label, tt.ok)
+		}
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	stanzas := ParseStanzas("Chorus\n\nVerse\n\nChorus")
+	collapsed := Collapse(stanzas)
+
+	want := []int{1, 1, 0}
+	for i, s := range collapsed {
+		if len(s.Lines) != want[i] {
+			t.Errorf("Collapse()[%d] has %d lines, want %d", i, len(s.Lines), want[i])
+		}
+	}
+	if len(stanzas[2].Lines) != 1 {
+		t.Errorf("Collapse() modified the source stanzas")
+	}
+}
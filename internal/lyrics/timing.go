@@ -0,0 +1,77 @@
+package lyrics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Word — слово строки с собственной отметкой времени (enhanced LRC)
+type Word struct {
+	Start time.Duration
+	Text  string
+}
+
+// Line — строка текста с отметкой начала
+type Line struct {
+	Start time.Duration
+	Text  string
+	Words []Word
+}
+
+// TimingError описывает нарушение порядка отметок времени.
+// Word равен -1, если ошибка относится к самой строке.
+type TimingError struct {
+	Line   int
+	Word   int
+	Reason string
+}
+
+func (e *TimingError) Error() string {
+	if e.Word >= 0 {
+		return fmt.Sprintf("line %d, word %d: %s", e.Line+1, e.Word+1, e.Reason)
+	}
+	return fmt.Sprintf("line %d: %s", e.Line+1, e.Reason)
+}
+
+// Validate проверяет, что строки и слова идут строго по возрастанию времени,
+// а слова не выходят за границы своей строки
+func Validate(lines []Line) error {
+	for i, line := range lines {
+		if line.Start < 0 {
+			return &TimingError{Line: i, Word: -1, Reason: "start must not be negative"}
+		}
+		if i > 0 && line.Start <= lines[i-1].Start {
+			return &TimingError{Line: i, Word: -1, Reason: "start must be after the previous line"}
+		}
+
+		for j, word := range line.Words {
+			if word.Start < line.Start {
+				return &TimingError{Line: i, Word: j, Reason: "start must not be before the line start"}
+			}
+			if j > 0 && word.Start <= line.Words[j-1].Start {
+				return &TimingError{Line: i, Word: j, Reason: "start must be after the previous word"}
+			}
+			if i+1 < len(lines) && word.Start >= lines[i+1].Start {
+				return &TimingError{Line: i, Word: j, Reason: "start must be before the next line"}
+			}
+		}
+	}
+	return nil
+}
+
+// At возвращает индекс строки, звучащей в момент t, или -1,
+// если воспроизведение ещё не дошло до первой строки.
+// Строки должны быть упорядочены по времени.
+func At(lines []Line, t time.Duration) int {
+	return sort.Search(len(lines), func(i int) bool {
+		return lines[i].Start > t
+	}) - 1
+}
+
+// WordAt возвращает индекс слова строки, звучащего в момент t, или -1
+func WordAt(line Line, t time.Duration) int {
+	return sort.Search(len(line.Words), func(i int) bool {
+		return line.Words[i].Start > t
+	}) - 1
+}
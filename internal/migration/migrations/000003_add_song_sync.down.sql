@@ -0,0 +1,3 @@
+DROP TABLE song_changes;
+DROP INDEX idx_songs_synced_at;
+ALTER TABLE songs DROP COLUMN synced_at;
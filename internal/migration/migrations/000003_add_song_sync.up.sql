@@ -0,0 +1,13 @@
+ALTER TABLE songs ADD COLUMN synced_at TIMESTAMP;
+CREATE INDEX idx_songs_synced_at ON songs (synced_at);
+
+CREATE TABLE song_changes (
+    id SERIAL PRIMARY KEY,
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    field TEXT NOT NULL,
+    old_value TEXT NOT NULL,
+    new_value TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_song_changes_status ON song_changes (status);
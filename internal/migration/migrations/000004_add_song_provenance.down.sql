@@ -0,0 +1,2 @@
+ALTER TABLE song_changes DROP COLUMN source;
+ALTER TABLE songs DROP COLUMN provenance;
@@ -0,0 +1,2 @@
+ALTER TABLE songs ADD COLUMN provenance JSONB;
+ALTER TABLE song_changes ADD COLUMN source TEXT NOT NULL DEFAULT '';
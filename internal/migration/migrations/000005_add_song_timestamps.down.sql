@@ -0,0 +1,2 @@
+ALTER TABLE songs DROP COLUMN updated_at;
+ALTER TABLE songs DROP COLUMN created_at;
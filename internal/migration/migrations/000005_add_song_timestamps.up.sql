@@ -0,0 +1,2 @@
+ALTER TABLE songs ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT NOW();
+ALTER TABLE songs ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT NOW();
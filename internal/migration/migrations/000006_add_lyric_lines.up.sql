@@ -0,0 +1,10 @@
+CREATE TABLE lyric_lines (
+    id SERIAL PRIMARY KEY,
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    start_ms BIGINT NOT NULL CHECK (start_ms >= 0),
+    text TEXT NOT NULL,
+    words JSONB,
+    UNIQUE (song_id, position),
+    UNIQUE (song_id, start_ms)
+);
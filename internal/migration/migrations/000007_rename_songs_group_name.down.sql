@@ -0,0 +1 @@
+ALTER TABLE songs RENAME COLUMN "group" TO group_name;
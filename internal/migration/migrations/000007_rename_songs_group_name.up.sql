@@ -0,0 +1,14 @@
+-- Сущность Song хранит исполнителя в столбце "group", а первая миграция
+-- создала group_name. Базы, где столбец уже переименован, не меняются.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'songs' AND column_name = 'group_name'
+    ) AND NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'songs' AND column_name = 'group'
+    ) THEN
+        ALTER TABLE songs RENAME COLUMN group_name TO "group";
+    END IF;
+END $$;
@@ -0,0 +1,3 @@
+DROP TABLE song_slugs;
+ALTER TABLE songs DROP COLUMN slug;
+ALTER TABLE songs DROP COLUMN public_id;
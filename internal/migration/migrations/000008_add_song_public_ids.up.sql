@@ -0,0 +1,16 @@
+ALTER TABLE songs ADD COLUMN public_id UUID;
+UPDATE songs SET public_id = gen_random_uuid();
+ALTER TABLE songs ALTER COLUMN public_id SET NOT NULL;
+CREATE UNIQUE INDEX idx_songs_public_id ON songs (public_id);
+
+-- слаги существующих песен заполняет приложение после миграций
+-- (SongRepository.BackfillSlugs), тем же алгоритмом, что и для новых песен
+ALTER TABLE songs ADD COLUMN slug VARCHAR(255);
+CREATE UNIQUE INDEX idx_songs_slug ON songs (slug);
+
+CREATE TABLE song_slugs (
+    slug VARCHAR(255) PRIMARY KEY,
+    song_id INTEGER NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_song_slugs_song_id ON song_slugs (song_id);
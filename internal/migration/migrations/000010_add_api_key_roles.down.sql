@@ -0,0 +1,10 @@
+ALTER TABLE api_keys ADD COLUMN scopes JSONB NOT NULL DEFAULT '[]';
+
+UPDATE api_keys SET scopes = CASE role
+    WHEN 'admin' THEN '["admin"]'::jsonb
+    WHEN 'editor' THEN '["songs:read", "songs:write"]'::jsonb
+    WHEN 'contributor' THEN '["songs:read", "songs:write"]'::jsonb
+    ELSE '["songs:read"]'::jsonb
+END;
+
+ALTER TABLE api_keys DROP COLUMN role;
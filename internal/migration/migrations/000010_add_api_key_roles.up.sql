@@ -0,0 +1,9 @@
+ALTER TABLE api_keys ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'viewer';
+
+UPDATE api_keys SET role = CASE
+    WHEN scopes ? 'admin' THEN 'admin'
+    WHEN scopes ? 'songs:write' THEN 'editor'
+    ELSE 'viewer'
+END;
+
+ALTER TABLE api_keys DROP COLUMN scopes;
@@ -0,0 +1,5 @@
+// Package musicinfo содержит клиент Music Info API, сгенерированный
+// из docs/openapi.yaml, и проверку ответов по схеме SongDetail.
+package musicinfo
+
+//go:generate oapi-codegen -generate types,client,server -package musicinfo -o api.go ../../docs/openapi.yaml
@@ -0,0 +1,44 @@
+package musicinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ReleaseDateLayout задаёт формат releaseDate в SongDetail
+const ReleaseDateLayout = "02.01.2006"
+
+// songDetailRequired повторяет список required схемы SongDetail
+var songDetailRequired = []string{"releaseDate", "text", "link"}
+
+// ParseSongDetail разбирает тело ответа и проверяет его по схеме SongDetail:
+// наличие обязательных полей, их тип и формат даты выпуска
+func ParseSongDetail(body []byte) (*SongDetail, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("response is not a JSON object: %w", err)
+	}
+
+	for _, field := range songDetailRequired {
+		value, ok := raw[field]
+		if !ok {
+			return nil, fmt.Errorf("missing required field %q", field)
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return nil, fmt.Errorf("field %q must be a string", field)
+		}
+	}
+
+	var detail SongDetail
+	if err := json.Unmarshal(body, &detail); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if _, err := time.Parse(ReleaseDateLayout, detail.ReleaseDate); err != nil {
+		return nil, fmt.Errorf("field \"releaseDate\" must match %s: %w", ReleaseDateLayout, err)
+	}
+
+	return &detail, nil
+}
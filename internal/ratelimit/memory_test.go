@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// clock — управляемые часы для MemoryLimiter
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+func newTestLimiter() (*MemoryLimiter, *clock) {
+	c := &clock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewMemoryLimiter()
+	l.now = c.now
+	return l, c
+}
+
+func TestMemoryLimiterAllow(t *testing.T) {
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 3}
+	l, c := newTestLimiter()
+
+	steps := []struct {
+		name       string
+		advance    time.Duration
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}{
+		{"первый запрос", 0, true, 2, 0},
+		{"второй запрос", 0, true, 1, 0},
+		{"последний токен burst", 0, true, 0, 0},
+		{"корзина пуста", 0, false, 0, time.Second},
+		{"отказ не тратит токен", 250 * time.Millisecond, false, 0, 750 * time.Millisecond},
+		{"токен восстановился", 750 * time.Millisecond, true, 0, 0},
+		{"пополнение до burst", time.Hour, true, 2, 0},
+	}
+	for _, s := range steps {
+		c.advance(s.advance)
+		r, err := l.Allow(context.Background(), "client", limit)
+		if err != nil {
+			t.Fatalf("%s: Allow() error = %v", s.name, err)
+		}
+		if r.Allowed != s.allowed || r.Remaining != s.remaining || r.RetryAfter != s.retryAfter {
+			t.Errorf("%s: Allow() = {Allowed: %v, Remaining: %d, RetryAfter: %v}, want {%v, %d, %v}",
+				s.name, r.Allowed, r.Remaining, r.RetryAfter, s.allowed, s.remaining, s.retryAfter)
+		}
+	}
+}
+
+func TestMemoryLimiterKeysAreIndependent(t *testing.T) {
+	limit := Limit{Requests: 1, Period: time.Minute, Burst: 1}
+	l, _ := newTestLimiter()
+
+	tests := []struct {
+		key     string
+		allowed bool
+	}{
+		{"a", true},
+		{"a", false},
+		{"b", true},
+		{"b", false},
+	}
+	for _, tt := range tests {
+		r, _ := l.Allow(context.Background(), tt.key, limit)
+		if r.Allowed != tt.allowed {
+			t.Errorf("Allow(%q) allowed = %v, want %v", tt.key, r.Allowed, tt.allowed)
+		}
+	}
+}
+
+func TestMemoryLimiterSweep(t *testing.T) {
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 10}
+	l, c := newTestLimiter()
+
+	// первый вызов сразу выполняет очистку, поэтому дальше она ждёт sweepInterval
+	l.Allow(context.Background(), "idle", limit)
+	l.Allow(context.Background(), "busy", Limit{Requests: 1, Period: time.Hour, Burst: 10})
+	if len(l.buckets) != 2 {
+		t.Fatalf("buckets = %d, want 2", len(l.buckets))
+	}
+
+	c.advance(sweepInterval / 2)
+	l.Allow(context.Background(), "other", limit)
+	if len(l.buckets) != 3 {
+		t.Errorf("buckets before sweepInterval = %d, want 3", len(l.buckets))
+	}
+
+	// за sweepInterval корзина idle восстановилась, а busy ещё нет
+	c.advance(sweepInterval)
+	l.Allow(context.Background(), "other", limit)
+	if _, ok := l.buckets["idle"]; ok {
+		t.Errorf("full bucket %q was not swept", "idle")
+	}
+	if _, ok := l.buckets["busy"]; !ok {
+		t.Errorf("partial bucket %q was swept", "busy")
+	}
+}
@@ -0,0 +1,71 @@
+// Package ratelimit ограничивает частоту запросов клиентов по алгоритму
+// token bucket: корзина вмещает Burst токенов, пополняется со скоростью
+// Requests за Period, и каждый запрос забирает один токен.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Limit — параметры корзины
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// Rate возвращает скорость пополнения корзины в токенах в секунду
+func (l Limit) Rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Policy описывает лимит для заголовка RateLimit-Policy
+func (l Limit) Policy() string {
+	return fmt.Sprintf("%d;w=%d;burst=%d", l.Requests, int(math.Ceil(l.Period.Seconds())), l.Burst)
+}
+
+// Result — итог попытки взять токен. Reset — время до полного
+// восстановления корзины, RetryAfter — до появления токена, если запрос
+// отклонён.
+type Result struct {
+	Limit      Limit
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Limiter списывает токен из корзины клиента key
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Refill возвращает число токенов в корзине спустя elapsed
+func Refill(tokens float64, elapsed time.Duration, limit Limit) float64 {
+	return math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate())
+}
+
+// NewResult строит итог по числу токенов, оставшихся после попытки
+func NewResult(limit Limit, allowed bool, tokens float64) Result {
+	rate := limit.Rate()
+	r := Result{
+		Limit:     limit,
+		Allowed:   allowed,
+		Remaining: max(int(math.Floor(tokens)), 0),
+		Reset:     seconds((float64(limit.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		r.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
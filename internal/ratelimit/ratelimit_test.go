@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefill(t *testing.T) {
+	// 60 запросов в минуту — токен в секунду
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 10}
+	tests := []struct {
+		name    string
+		tokens  float64
+		elapsed time.Duration
+		want    float64
+	}{
+		{"без времени", 3, 0, 3},
+		{"полсекунды", 3, 500 * time.Millisecond, 3.5},
+		{"несколько секунд", 0, 4 * time.Second, 4},
+		{"до burst", 9, 5 * time.Second, 10},
+		{"не выше burst", 0, time.Hour, 10},
+		{"из отрицательного остатка", -1, 2 * time.Second, 1},
+	}
+	for _, tt := range tests {
+		if got := Refill(tt.tokens, tt.elapsed, limit); got != tt.want {
+			t.Errorf("%s: Refill(%v, %v) = %v, want %v", tt.name, tt.tokens, tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 10}
+	tests := []struct {
+		name       string
+		allowed    bool
+		tokens     float64
+		remaining  int
+		reset      time.Duration
+		retryAfter time.Duration
+	}{
+		{"полная после списания", true, 9, 9, time.Second, 0},
+		{"дробный остаток", true, 2.5, 2, 7500 * time.Millisecond, 0},
+		{"последний токен", true, 0, 0, 10 * time.Second, 0},
+		{"отказ", false, 0.25, 0, 9750 * time.Millisecond, 750 * time.Millisecond},
+		{"отказ на пустой", false, 0, 0, 10 * time.Second, time.Second},
+	}
+	for _, tt := range tests {
+		r := NewResult(limit, tt.allowed, tt.tokens)
+		if r.Allowed != tt.allowed || r.Remaining != tt.remaining || r.Reset != tt.reset || r.RetryAfter != tt.retryAfter {
+			t.Errorf("%s: NewResult(%v, %v) = {Allowed: %v, Remaining: %d, Reset: %v, RetryAfter: %v}, want {%v, %d, %v, %v}",
+				tt.name, tt.allowed, tt.tokens, r.Allowed, r.Remaining, r.Reset, r.RetryAfter,
+				tt.allowed, tt.remaining, tt.reset, tt.retryAfter)
+		}
+		if r.Limit != limit {
+			t.Errorf("%s: Limit = %+v, want %+v", tt.name, r.Limit, limit)
+		}
+	}
+}
+
+func TestLimitPolicy(t *testing.T) {
+	tests := []struct {
+		limit Limit
+		want  string
+	}{
+		{Limit{Requests: 60, Period: time.Minute, Burst: 20}, "60;w=60;burst=20"},
+		{Limit{Requests: 5, Period: 1500 * time.Millisecond, Burst: 5}, "5;w=2;burst=5"},
+	}
+	for _, tt := range tests {
+		if got := tt.limit.Policy(); got != tt.want {
+			t.Errorf("Policy(%+v) = %q, want %q", tt.limit, got, tt.want)
+		}
+	}
+}
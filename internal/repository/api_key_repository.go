@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewAPIKeyRepository(db *gorm.DB, log *logrus.Logger) *APIKeyRepository {
+	return &APIKeyRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *APIKeyRepository) Create(key *entity.APIKey) error {
+	err := r.db.Create(key).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"name":  key.Name,
+			"owner": key.Owner,
+		}).Error("Failed to create API key")
+	}
+	return err
+}
+
+func (r *APIKeyRepository) GetAll() ([]entity.APIKey, error) {
+	var keys []entity.APIKey
+	err := r.db.Order("id").Find(&keys).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get API keys")
+	}
+	return keys, err
+}
+
+func (r *APIKeyRepository) GetByID(id uint) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.First(&key, id).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to get API key by ID")
+	}
+	return &key, err
+}
+
+// GetByHash находит ключ по хешу предъявленного секрета
+func (r *APIKeyRepository) GetByHash(hash string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.db.Where("hash = ?", hash).First(&key).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithError(err).Error("Failed to get API key by hash")
+	}
+	return &key, err
+}
+
+func (r *APIKeyRepository) Update(key *entity.APIKey) error {
+	err := r.db.Save(key).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    key.ID,
+		}).Error("Failed to update API key")
+	}
+	return err
+}
+
+// TouchLastUsed обновляет время последнего использования ключа
+func (r *APIKeyRepository) TouchLastUsed(id uint, at time.Time) error {
+	err := r.db.Model(&entity.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to update API key last use")
+	}
+	return err
+}
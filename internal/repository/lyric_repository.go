@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LyricRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewLyricRepository(db *gorm.DB, log *logrus.Logger) *LyricRepository {
+	return &LyricRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+func (r *LyricRepository) GetBySong(songID uint) ([]entity.LyricLine, error) {
+	var lines []entity.LyricLine
+	err := r.db.Where("song_id = ?", songID).Order("position").Find(&lines).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"song_id": songID,
+		}).Error("Failed to get lyric lines")
+	}
+	return lines, err
+}
+
+// GetBySongs возвращает строки нескольких песен, упорядоченные по песне и позиции
+func (r *LyricRepository) GetBySongs(songIDs []uint) ([]entity.LyricLine, error) {
+	var lines []entity.LyricLine
+	err := r.db.Where("song_id IN ?", songIDs).Order("song_id, position").Find(&lines).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"song_ids": songIDs,
+		}).Error("Failed to get lyric lines")
+	}
+	return lines, err
+}
+
+// Replace заменяет разметку песни целиком
+func (r *LyricRepository) Replace(songID uint, lines []entity.LyricLine) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("song_id = ?", songID).Delete(&entity.LyricLine{}).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+		for i := range lines {
+			lines[i].SongID = songID
+			lines[i].Position = i
+		}
+		return tx.Create(&lines).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"song_id": songID,
+			"lines":   len(lines),
+		}).Error("Failed to replace lyric lines")
+	}
+	return err
+}
+
+func (r *LyricRepository) DeleteBySong(songID uint) (int64, error) {
+	result := r.db.Where("song_id = ?", songID).Delete(&entity.LyricLine{})
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   result.Error,
+			"song_id": songID,
+		}).Error("Failed to delete lyric lines")
+	}
+	return result.RowsAffected, result.Error
+}
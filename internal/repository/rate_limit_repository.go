@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/ratelimit"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// bucketSweepInterval — как часто из таблицы удаляются восстановившиеся корзины
+const bucketSweepInterval = 5 * time.Minute
+
+// takeTokenSQL пополняет корзину и списывает токен одним запросом, поэтому
+// параллельные запросы к разным репликам не превышают общий лимит.
+// Время берётся у базы, чтобы расхождение часов реплик не влияло на лимит.
+var takeTokenSQL = expandSQL(`
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at, full_at)
+VALUES (@key, {burst} - 1, TRUE, NOW(), NOW() + make_interval(secs => 1 / {rate}))
+ON CONFLICT (key) DO UPDATE SET
+    tokens = {taken},
+    allowed = {refilled} >= 1,
+    updated_at = NOW(),
+    full_at = NOW() + make_interval(secs => ({burst} - {taken}) / {rate})
+RETURNING tokens, allowed`,
+	"{taken}", "CASE WHEN {refilled} >= 1 THEN {refilled} - 1 ELSE {refilled} END",
+	"{refilled}", "LEAST({burst}, b.tokens + CAST(EXTRACT(EPOCH FROM NOW() - b.updated_at) AS DOUBLE PRECISION) * {rate})",
+	"{burst}", "CAST(@burst AS DOUBLE PRECISION)",
+	"{rate}", "CAST(@rate AS DOUBLE PRECISION)",
+)
+
+// expandSQL по порядку подставляет в запрос повторяющиеся выражения
+func expandSQL(query string, pairs ...string) string {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		query = strings.ReplaceAll(query, pairs[i], pairs[i+1])
+	}
+	return query
+}
+
+// RateLimitRepository хранит корзины ограничения частоты запросов в базе,
+// общей для всех реплик сервера
+type RateLimitRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+
+	mu    sync.Mutex
+	swept time.Time
+}
+
+func NewRateLimitRepository(db *gorm.DB, log *logrus.Logger) *RateLimitRepository {
+	return &RateLimitRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Allow списывает токен из корзины key
+func (r *RateLimitRepository) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	var row struct {
+		Tokens  float64
+		Allowed bool
+	}
+	err := r.db.WithContext(ctx).Raw(takeTokenSQL, map[string]any{
+		"key":   key,
+		"burst": limit.Burst,
+		"rate":  limit.Rate(),
+	}).Scan(&row).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"key":   key,
+		}).Error("Failed to take rate limit token")
+		return ratelimit.Result{}, err
+	}
+
+	r.sweep()
+	return ratelimit.NewResult(limit, row.Allowed, row.Tokens), nil
+}
+
+// sweep удаляет полные корзины: они ничем не отличаются от новых
+func (r *RateLimitRepository) sweep() {
+	r.mu.Lock()
+	due := time.Since(r.swept) >= bucketSweepInterval
+	if due {
+		r.swept = time.Now()
+	}
+	r.mu.Unlock()
+	if !due {
+		return
+	}
+
+	if err := r.db.Exec("DELETE FROM rate_limit_buckets WHERE full_at < NOW()").Error; err != nil {
+		r.logger.WithError(err).Warn("Failed to delete full rate limit buckets")
+	}
+}
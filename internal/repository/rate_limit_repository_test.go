@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandSQL(t *testing.T) {
+	tests := []struct {
+		query string
+		pairs []string
+		want  string
+	}{
+		{"SELECT {a}", []string{"{a}", "1"}, "SELECT 1"},
+		{"{a} + {a}", []string{"{a}", "x"}, "x + x"},
+		// подстановки применяются по порядку, поэтому могут ссылаться на следующие
+		{"{a}", []string{"{a}", "{b} * 2", "{b}", "@n"}, "@n * 2"},
+		{"{a}", nil, "{a}"},
+	}
+	for _, tt := range tests {
+		if got := expandSQL(tt.query, tt.pairs...); got != tt.want {
+			t.Errorf("expandSQL(%q, %q) = %q, want %q", tt.query, tt.pairs, got, tt.want)
+		}
+	}
+}
+
+func TestTakeTokenSQL(t *testing.T) {
+	if strings.ContainsAny(takeTokenSQL, "{}") {
+		t.Errorf("takeTokenSQL has unexpanded placeholders:\n%s", takeTokenSQL)
+	}
+	for _, param := range []string{"@key", "@burst", "@rate"} {
+		if !strings.Contains(takeTokenSQL, param) {
+			t.Errorf("takeTokenSQL does not use %s", param)
+		}
+	}
+	// отказ не должен списывать токен: вычитание только при refilled >= 1
+	if !strings.Contains(takeTokenSQL, "THEN LEAST(") || !strings.Contains(takeTokenSQL, ") - 1 ELSE LEAST(") {
+		t.Errorf("takeTokenSQL does not guard the token decrement:\n%s", takeTokenSQL)
+	}
+}
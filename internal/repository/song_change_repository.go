@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SongChangeRepository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewSongChangeRepository(db *gorm.DB, log *logrus.Logger) *SongChangeRepository {
+	return &SongChangeRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Queue ставит изменение в очередь, заменяя ожидающее изменение того же поля
+func (r *SongChangeRepository) Queue(change *entity.SongChange) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("song_id = ? AND field = ? AND status = ?",
+			change.SongID, change.Field, entity.SongChangePending,
+		).Delete(&entity.SongChange{}).Error; err != nil {
+			return err
+		}
+		change.Status = entity.SongChangePending
+		return tx.Create(change).Error
+	})
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"song_id": change.SongID,
+			"field":   change.Field,
+		}).Error("Failed to queue song change")
+	}
+	return err
+}
+
+func (r *SongChangeRepository) GetByStatus(status string) ([]entity.SongChange, error) {
+	var changes []entity.SongChange
+	err := r.db.Where("status = ?", status).Order("id").Find(&changes).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"status": status,
+		}).Error("Failed to get song changes")
+	}
+	return changes, err
+}
+
+func (r *SongChangeRepository) GetByID(id uint) (*entity.SongChange, error) {
+	var change entity.SongChange
+	err := r.db.First(&change, id).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to get song change by ID")
+	}
+	return &change, err
+}
+
+func (r *SongChangeRepository) SetStatus(id uint, status string) error {
+	result := r.db.Model(&entity.SongChange{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  result.Error,
+			"id":     id,
+			"status": status,
+		}).Error("Failed to update song change status")
+	}
+	return result.Error
+}
+
+// ErrChangeNotPending — изменение уже применено или отклонено другим запросом
+var ErrChangeNotPending = errors.New("song change is not pending")
+
+// Apply в одной транзакции блокирует песню, передаёт её в apply и сохраняет
+// вместе со статусом изменения applied. Ошибка apply откатывает транзакцию
+// и возвращается как есть.
+func (r *SongChangeRepository) Apply(change *entity.SongChange, apply func(song *entity.Song) error) error {
+	var applyErr error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var song entity.Song
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&song, change.SongID).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&entity.SongChange{}).
+			Where("id = ? AND status = ?", change.ID, entity.SongChangePending).
+			Update("status", entity.SongChangeApplied)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrChangeNotPending
+		}
+		if applyErr = apply(&song); applyErr != nil {
+			return applyErr
+		}
+		return updateSong(tx, &song)
+	})
+	if err != nil && applyErr == nil && !errors.Is(err, ErrChangeNotPending) {
+		r.logger.WithFields(logrus.Fields{
+			"error":   err,
+			"id":      change.ID,
+			"song_id": change.SongID,
+		}).Error("Failed to apply song change")
+	}
+	return err
+}
@@ -1,9 +1,18 @@
 package repository
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/slug"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SongRepository struct {
@@ -18,28 +27,225 @@ func NewSongRepository(db *gorm.DB, log *logrus.Logger) *SongRepository {
 	}
 }
 
+// maxSlugAttempts ограничивает повторы записи, когда параллельный запрос
+// занимает тот же слаг между его выбором и сохранением песни
+const maxSlugAttempts = 5
+
+// uniqueViolation — код ошибки PostgreSQL при нарушении уникальности
+const uniqueViolation = "23505"
+
+// ErrSlugConflict — свободный слаг не удалось занять за maxSlugAttempts попыток
+var ErrSlugConflict = errors.New("song slug is taken by concurrent writes")
+
+// Create сохраняет новую песню, назначая ей публичный ID и свободный слаг
 func (r *SongRepository) Create(song *entity.Song) error {
-	result := r.db.Create(song)
-	if result.Error != nil {
+	err := withSlugRetry(func() error {
+		return r.db.Transaction(func(tx *gorm.DB) error {
+			if song.PublicID == uuid.Nil {
+				id, err := uuid.NewV7()
+				if err != nil {
+					return err
+				}
+				song.PublicID = id
+			}
+			s, err := freeSlug(tx, slug.Song(song.Group, song.Title), 0)
+			if err != nil {
+				return err
+			}
+			song.Slug = s
+			return tx.Create(song).Error
+		})
+	})
+	if err != nil {
 		r.logger.WithFields(logrus.Fields{
-			"error": result.Error,
+			"error": err,
 			"group": song.Group,
 			"title": song.Title,
 		}).Error("Failed to create song")
 	}
-	return result.Error
+	return err
 }
 
-func (r *SongRepository) GetPaginated(filter map[string]string, page, size int) ([]entity.Song, error) {
-	var songs []entity.Song
-	query := r.db.Model(&entity.Song{})
+// freeSlug подбирает первый слаг вида base, base-2, base-3…, не занятый
+// другими песнями ни как текущий, ни как прежний
+func freeSlug(tx *gorm.DB, base string, songID uint) (string, error) {
+	for n := 1; ; n++ {
+		candidate := slug.WithSuffix(base, n)
+		var taken int64
+		err := tx.Raw(`SELECT
+			(SELECT COUNT(*) FROM songs WHERE slug = ? AND id <> ?) +
+			(SELECT COUNT(*) FROM song_slugs WHERE slug = ? AND song_id <> ?)`,
+			candidate, songID, candidate, songID).Scan(&taken).Error
+		if err != nil {
+			return "", err
+		}
+		if taken == 0 {
+			return candidate, nil
+		}
+	}
+}
+
+// withSlugRetry повторяет запись fn, пока слаг занимают параллельные запросы
+func withSlugRetry(fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if !isSlugViolation(err) {
+			return err
+		}
+		if attempt == maxSlugAttempts {
+			return ErrSlugConflict
+		}
+	}
+}
+
+// isSlugViolation сообщает, нарушена ли уникальность текущего или прежнего слага
+func isSlugViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation &&
+		(pgErr.ConstraintName == "idx_songs_slug" || pgErr.ConstraintName == "song_slugs_pkey")
+}
+
+// BackfillSlugs назначает слаги песням, добавленным до их появления, тем же
+// алгоритмом, что и при создании песни. Возвращает число обновлённых песен.
+func (r *SongRepository) BackfillSlugs() (int, error) {
+	var ids []uint
+	if err := r.db.Model(&entity.Song{}).Where("slug IS NULL").Order("id").Pluck("id", &ids).Error; err != nil {
+		r.logger.WithError(err).Error("Failed to get songs without slugs")
+		return 0, err
+	}
+
+	for i, id := range ids {
+		err := withSlugRetry(func() error {
+			return r.db.Transaction(func(tx *gorm.DB) error {
+				var song entity.Song
+				if err := tx.Select("id", "group", "title").First(&song, id).Error; err != nil {
+					return err
+				}
+				s, err := freeSlug(tx, slug.Song(song.Group, song.Title), id)
+				if err != nil {
+					return err
+				}
+				return tx.Model(&entity.Song{}).Where("id = ? AND slug IS NULL", id).Update("slug", s).Error
+			})
+		})
+		if err != nil {
+			r.logger.WithFields(logrus.Fields{
+				"error": err,
+				"id":    id,
+			}).Error("Failed to backfill song slug")
+			return i, err
+		}
+	}
+	return len(ids), nil
+}
+
+// slugMatches сообщает, построен ли слаг из base, возможно с номером
+func slugMatches(current, base string) bool {
+	if current == base {
+		return true
+	}
+	n, ok := strings.CutPrefix(current, base+"-")
+	if !ok || n == "" {
+		return false
+	}
+	_, err := strconv.Atoi(n)
+	return err == nil
+}
+
+// SongOrder задаёт сортировку списка песен
+type SongOrder struct {
+	Field string
+	Desc  bool
+}
+
+// songOrderColumns перечисляет поля, по которым разрешена сортировка
+var songOrderColumns = map[string]string{
+	"id":           "id",
+	"group":        `"group"`,
+	"title":        "title",
+	"release_date": "release_date",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+// IsSongOrderField сообщает, разрешена ли сортировка по полю
+func IsSongOrderField(field string) bool {
+	_, ok := songOrderColumns[field]
+	return ok
+}
+
+func (o SongOrder) clause() string {
+	column, ok := songOrderColumns[o.Field]
+	if !ok {
+		column = "id"
+	}
+	if o.Desc {
+		return column + " DESC, id DESC"
+	}
+	return column + ", id"
+}
+
+// songFieldColumns перечисляет поля песни, которые можно выбрать выборочно
+var songFieldColumns = map[string]string{
+	"id":           "id",
+	"public_id":    "public_id",
+	"slug":         "slug",
+	"group":        "group",
+	"title":        "title",
+	"release_date": "release_date",
+	"text":         "text",
+	"link":         "link",
+	"synced_at":    "synced_at",
+	"provenance":   "provenance",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+}
+
+// IsSongField сообщает, можно ли выбрать поле песни
+func IsSongField(field string) bool {
+	_, ok := songFieldColumns[field]
+	return ok
+}
 
+// selectFields ограничивает запрос перечисленными полями песни
+func selectFields(query *gorm.DB, fields []string) *gorm.DB {
+	if len(fields) == 0 {
+		return query
+	}
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if column, ok := songFieldColumns[field]; ok {
+			columns = append(columns, column)
+		}
+	}
+	return query.Select(columns)
+}
+
+// songFilterColumns перечисляет поля, по которым разрешена фильтрация
+var songFilterColumns = map[string]string{
+	"group": `"group"`,
+	"title": "title",
+}
+
+// whereFilter добавляет к запросу условия фильтра. Ключи вне
+// songFilterColumns пропускаются.
+func whereFilter(query *gorm.DB, filter map[string]string) *gorm.DB {
 	for key, value := range filter {
-		query = query.Where(key+" = ?", value)
+		if column, ok := songFilterColumns[key]; ok {
+			query = query.Where(column+" = ?", value)
+		}
 	}
+	return query
+}
+
+// GetPaginated возвращает страницу песен. Если fields не пуст,
+// из базы читаются только перечисленные поля.
+func (r *SongRepository) GetPaginated(filter map[string]string, order SongOrder, fields []string, page, size int) ([]entity.Song, error) {
+	var songs []entity.Song
+	query := whereFilter(selectFields(r.db.Model(&entity.Song{}), fields), filter)
 
 	offset := (page - 1) * size
-	err := query.Limit(size).Offset(offset).Find(&songs).Error
+	err := query.Order(order.clause()).Limit(size).Offset(offset).Find(&songs).Error
 
 	if err != nil {
 		r.logger.WithFields(logrus.Fields{
@@ -53,6 +259,20 @@ func (r *SongRepository) GetPaginated(filter map[string]string, page, size int)
 	return songs, err
 }
 
+// Count возвращает число песен, подходящих под фильтр
+func (r *SongRepository) Count(filter map[string]string) (int64, error) {
+	var total int64
+	err := whereFilter(r.db.Model(&entity.Song{}), filter).Count(&total).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"filter": filter,
+		}).Error("Failed to count songs")
+	}
+
+	return total, err
+}
+
 func (r *SongRepository) GetByID(id uint) (*entity.Song, error) {
 	var song entity.Song
 	err := r.db.First(&song, id).Error
@@ -67,16 +287,185 @@ func (r *SongRepository) GetByID(id uint) (*entity.Song, error) {
 	return &song, err
 }
 
-// Update обновляет данные песни
+// GetByIDs возвращает песни с указанными внутренними или публичными ID
+// одним запросом, в порядке ID. Отсутствующие ID пропускаются.
+func (r *SongRepository) GetByIDs(ids []uint, publicIDs []uuid.UUID, fields []string) ([]entity.Song, error) {
+	var songs []entity.Song
+	query := selectFields(r.db.Model(&entity.Song{}), fields)
+	switch {
+	case len(ids) > 0 && len(publicIDs) > 0:
+		query = query.Where("id IN ? OR public_id IN ?", ids, publicIDs)
+	case len(publicIDs) > 0:
+		query = query.Where("public_id IN ?", publicIDs)
+	default:
+		query = query.Where("id IN ?", ids)
+	}
+	err := query.Order("id").Find(&songs).Error
+
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":      err,
+			"ids":        ids,
+			"public_ids": publicIDs,
+		}).Error("Failed to get songs by IDs")
+	}
+
+	return songs, err
+}
+
+// GetIDByPublicID возвращает внутренний ID песни по её публичному ID
+func (r *SongRepository) GetIDByPublicID(publicID uuid.UUID) (uint, error) {
+	var song entity.Song
+	err := r.db.Select("id").Where("public_id = ?", publicID).First(&song).Error
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"error":     err,
+			"public_id": publicID,
+		}).Error("Failed to get song by public ID")
+	}
+
+	return song.ID, err
+}
+
+// GetBySlug возвращает песню по текущему слагу
+func (r *SongRepository) GetBySlug(s string) (*entity.Song, error) {
+	var song entity.Song
+	err := r.db.Where("slug = ?", s).First(&song).Error
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"slug":  s,
+		}).Error("Failed to get song by slug")
+	}
+
+	return &song, err
+}
+
+// GetByPreviousSlug возвращает песню, которой раньше принадлежал слаг
+func (r *SongRepository) GetByPreviousSlug(s string) (*entity.Song, error) {
+	var song entity.Song
+	err := r.db.Joins("JOIN song_slugs ON song_slugs.song_id = songs.id").
+		Where("song_slugs.slug = ?", s).
+		First(&song).Error
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"slug":  s,
+		}).Error("Failed to get song by previous slug")
+	}
+
+	return &song, err
+}
+
+// GetByGroupAndTitle находит песню по исполнителю и названию без учёта регистра
+func (r *SongRepository) GetByGroupAndTitle(group, title string) (*entity.Song, error) {
+	var song entity.Song
+	err := r.db.Where(`LOWER("group") = LOWER(?) AND LOWER(title) = LOWER(?)`, group, title).
+		Order("id").
+		First(&song).Error
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"group": group,
+			"title": title,
+		}).Error("Failed to get song by group and title")
+	}
+
+	return &song, err
+}
+
+// Update сохраняет песню. Если изменились исполнитель или название,
+// песня получает новый слаг, а прежний остаётся в истории для перенаправления.
+// Если изменился текст, разметка строк по времени удаляется: её строки
+// больше не соответствуют тексту.
 func (r *SongRepository) Update(song *entity.Song) error {
-	result := r.db.Save(song)
-	if result.Error != nil {
+	previous := song.Slug
+	err := withSlugRetry(func() error {
+		song.Slug = previous
+		return r.db.Transaction(func(tx *gorm.DB) error {
+			return updateSong(tx, song)
+		})
+	})
+	if err != nil {
 		r.logger.WithFields(logrus.Fields{
-			"error": result.Error,
+			"error": err,
 			"id":    song.ID,
 		}).Error("Failed to update song")
 	}
-	return result.Error
+	return err
+}
+
+// UpdateColumns блокирует строку песни, передаёт её свежую копию в apply и
+// записывает только колонки, которые вернул apply. Если среди них есть text,
+// разметка строк по времени удаляется.
+func (r *SongRepository) UpdateColumns(id uint, apply func(song *entity.Song) ([]string, error)) error {
+	var applyErr error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var song entity.Song
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&song, id).Error; err != nil {
+			return err
+		}
+		var columns []string
+		if columns, applyErr = apply(&song); applyErr != nil {
+			return applyErr
+		}
+		if len(columns) == 0 {
+			return nil
+		}
+		for _, column := range columns {
+			if column != "text" {
+				continue
+			}
+			if err := tx.Where("song_id = ?", id).Delete(&entity.LyricLine{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&song).Select(columns).Updates(&song).Error
+	})
+	if err != nil && applyErr == nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to update song columns")
+	}
+	return err
+}
+
+// updateSong сохраняет песню в транзакции tx, перенося слаг в историю при
+// переименовании и удаляя разметку при изменении текста
+func updateSong(tx *gorm.DB, song *entity.Song) error {
+	var stored entity.Song
+	if err := tx.Select("group", "title", "text").First(&stored, song.ID).Error; err != nil {
+		return err
+	}
+	renamed := stored.Group != song.Group || stored.Title != song.Title
+	base := slug.Song(song.Group, song.Title)
+	if renamed && !slugMatches(song.Slug, base) {
+		s, err := freeSlug(tx, base, song.ID)
+		if err != nil {
+			return err
+		}
+		// слаг мог вернуться к одному из прежних
+		if err := tx.Where("slug = ?", s).Delete(&entity.SongSlug{}).Error; err != nil {
+			return err
+		}
+		if song.Slug != "" {
+			if err := tx.Create(&entity.SongSlug{Slug: song.Slug, SongID: song.ID}).Error; err != nil {
+				return err
+			}
+		}
+		song.Slug = s
+	}
+	if stored.Text != song.Text {
+		if err := tx.Where("song_id = ?", song.ID).Delete(&entity.LyricLine{}).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Save(song).Error
 }
 
 // Delete удаляет песню по её ID
@@ -87,6 +476,93 @@ func (r *SongRepository) Delete(id uint) error {
 			"error": result.Error,
 			"id":    id,
 		}).Error("Failed to delete song")
+		return result.Error
 	}
-	return result.Error
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetStale возвращает песни, не синхронизированные с провайдером после before
+func (r *SongRepository) GetStale(filter map[string]string, before time.Time, limit int) ([]entity.Song, error) {
+	var songs []entity.Song
+	query := whereFilter(r.db.Model(&entity.Song{}), filter).
+		Where("synced_at IS NULL OR synced_at < ?", before)
+
+	err := query.Order("synced_at NULLS FIRST").Limit(limit).Find(&songs).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"filter": filter,
+			"before": before,
+		}).Error("Failed to get stale songs")
+	}
+
+	return songs, err
+}
+
+// GroupCount — исполнитель и число его песен
+type GroupCount struct {
+	Group string
+	Songs int64
+}
+
+// GetGroups возвращает страницу исполнителей в алфавитном порядке
+func (r *SongRepository) GetGroups(page, size int) ([]GroupCount, error) {
+	var groups []GroupCount
+	err := r.db.Model(&entity.Song{}).
+		Select(`"group" AS "group", COUNT(*) AS songs`).
+		Group(`"group"`).
+		Order(`"group"`).
+		Limit(size).Offset((page - 1) * size).
+		Scan(&groups).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error": err,
+			"page":  page,
+			"size":  size,
+		}).Error("Failed to get groups")
+	}
+	return groups, err
+}
+
+// CountGroups возвращает число различных исполнителей
+func (r *SongRepository) CountGroups() (int64, error) {
+	var total int64
+	err := r.db.Model(&entity.Song{}).Distinct(`"group"`).Count(&total).Error
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to count groups")
+	}
+	return total, err
+}
+
+// CountByGroups возвращает число песен каждого из исполнителей одним запросом
+func (r *SongRepository) CountByGroups(groups []string) ([]GroupCount, error) {
+	var counts []GroupCount
+	err := r.db.Model(&entity.Song{}).
+		Select(`"group" AS "group", COUNT(*) AS songs`).
+		Where(`"group" IN ?`, groups).
+		Group(`"group"`).
+		Scan(&counts).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"groups": len(groups),
+		}).Error("Failed to count songs by groups")
+	}
+	return counts, err
+}
+
+// GetByGroups возвращает песни нескольких исполнителей одним запросом
+func (r *SongRepository) GetByGroups(groups []string) ([]entity.Song, error) {
+	var songs []entity.Song
+	err := r.db.Where(`"group" IN ?`, groups).Order("title, id").Find(&songs).Error
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"groups": len(groups),
+		}).Error("Failed to get songs by groups")
+	}
+	return songs, err
 }
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSlugMatches(t *testing.T) {
+	tests := []struct {
+		current, base string
+		want          bool
+	}{
+		{"queen/innuendo", "queen/innuendo", true},
+		{"queen/innuendo-2", "queen/innuendo", true},
+		{"queen/innuendo-17", "queen/innuendo", true},
+		{"queen/innuendo-", "queen/innuendo", false},
+		{"queen/innuendo-live", "queen/innuendo", false},
+		{"queen/innuendo2", "queen/innuendo", false},
+		{"queen/bicycle-race", "queen/innuendo", false},
+		{"", "queen/innuendo", false},
+	}
+	for _, tt := range tests {
+		if got := slugMatches(tt.current, tt.base); got != tt.want {
+			t.Errorf("slugMatches(%q, %q) = %v, want %v", tt.current, tt.base, got, tt.want)
+		}
+	}
+}
+
+func TestWithSlugRetry(t *testing.T) {
+	slugTaken := &pgconn.PgError{Code: uniqueViolation, ConstraintName: "idx_songs_slug"}
+
+	t.Run("retries slug violations", func(t *testing.T) {
+		calls := 0
+		err := withSlugRetry(func() error {
+			calls++
+			if calls < 3 {
+				return fmt.Errorf("create: %w", slugTaken)
+			}
+			return nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("got err=%v after %d calls, want success after 3", err, calls)
+		}
+	})
+
+	t.Run("gives up with ErrSlugConflict", func(t *testing.T) {
+		calls := 0
+		err := withSlugRetry(func() error {
+			calls++
+			return slugTaken
+		})
+		if !errors.Is(err, ErrSlugConflict) || calls != maxSlugAttempts {
+			t.Errorf("got err=%v after %d calls, want ErrSlugConflict after %d", err, calls, maxSlugAttempts)
+		}
+	})
+
+	t.Run("returns other errors at once", func(t *testing.T) {
+		other := &pgconn.PgError{Code: uniqueViolation, ConstraintName: "idx_songs_public_id"}
+		calls := 0
+		err := withSlugRetry(func() error {
+			calls++
+			return other
+		})
+		if !errors.Is(err, other) || calls != 1 {
+			t.Errorf("got err=%v after %d calls, want the original error after 1", err, calls)
+		}
+	})
+}
+
+func TestWhereFilter(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		filter map[string]string
+		want   string
+	}{
+		// group — зарезервированное слово, без кавычек запрос не выполнится
+		{"исполнитель", map[string]string{"group": "Muse"}, `WHERE "group" = $1`},
+		{"название", map[string]string{"title": "Uprising"}, `WHERE title = $1`},
+		{"неизвестный ключ", map[string]string{"1=1 OR slug": "x"}, `FROM "songs"`},
+	}
+	for _, tt := range tests {
+		var songs []entity.Song
+		stmt := whereFilter(db.Model(&entity.Song{}), tt.filter).Find(&songs).Statement
+		sql := stmt.SQL.String()
+		if !strings.HasSuffix(sql, tt.want) {
+			t.Errorf("%s: SQL = %q, want suffix %q", tt.name, sql, tt.want)
+		}
+	}
+}
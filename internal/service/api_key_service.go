@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// lastUsedResolution — точность времени последнего использования ключа:
+// чаще обновлять запись при каждом запросе незачем
+const lastUsedResolution = time.Minute
+
+type APIKeyService struct {
+	repo   *repository.APIKeyRepository
+	config config.Auth
+	logger *logrus.Logger
+}
+
+func NewAPIKeyService(repo *repository.APIKeyRepository, cfg *config.Config, log *logrus.Logger) *APIKeyService {
+	return &APIKeyService{
+		repo:   repo,
+		config: cfg.Auth,
+		logger: log,
+	}
+}
+
+// NewAPIKey — параметры создаваемого ключа
+type NewAPIKey struct {
+	Name      string
+	Owner     string
+	Role      string
+	ExpiresAt *time.Time
+}
+
+// CreateKey создаёт ключ и возвращает его запись и сам ключ.
+// Ключ не сохраняется и больше не может быть показан.
+func (s *APIKeyService) CreateKey(ctx context.Context, req NewAPIKey) (*entity.APIKey, string, error) {
+	if !auth.IsRole(req.Role) {
+		return nil, "", apperror.Validation("unknown_role", fmt.Sprintf("Unknown role %q", req.Role), nil)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, "", apperror.Validation("invalid_expiry", "Expiry must be in the future", nil)
+	}
+
+	secret, prefix, hash, err := auth.GenerateKey()
+	if err != nil {
+		return nil, "", err
+	}
+	key := &entity.APIKey{
+		Name:      req.Name,
+		Owner:     req.Owner,
+		Prefix:    prefix,
+		Hash:      hash,
+		Role:      req.Role,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.repo.Create(key); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"id":    key.ID,
+		"name":  key.Name,
+		"owner": key.Owner,
+		"role":  key.Role,
+	}).Info("API key created")
+
+	return key, secret, nil
+}
+
+// GetKeys возвращает все ключи, включая отозванные
+func (s *APIKeyService) GetKeys(ctx context.Context) ([]entity.APIKey, error) {
+	return s.repo.GetAll()
+}
+
+// GetKey возвращает ключ по ID
+func (s *APIKeyService) GetKey(ctx context.Context, id uint) (*entity.APIKey, error) {
+	key, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, apiKeyError(id, err)
+	}
+	return key, nil
+}
+
+// RotateKey выдаёт ключу новый секрет; прежний сразу перестаёт действовать
+func (s *APIKeyService) RotateKey(ctx context.Context, id uint) (*entity.APIKey, string, error) {
+	key, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, "", apiKeyError(id, err)
+	}
+	if key.RevokedAt != nil {
+		return nil, "", apperror.Conflict("key_revoked", fmt.Sprintf("API key %d is revoked", id), nil)
+	}
+
+	secret, prefix, hash, err := auth.GenerateKey()
+	if err != nil {
+		return nil, "", err
+	}
+	key.Prefix = prefix
+	key.Hash = hash
+	key.LastUsedAt = nil
+	if err := s.repo.Update(key); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"id":    key.ID,
+		"owner": key.Owner,
+	}).Info("API key rotated")
+
+	return key, secret, nil
+}
+
+// RevokeKey отзывает ключ. Повторный отзыв ничего не меняет.
+func (s *APIKeyService) RevokeKey(ctx context.Context, id uint) (*entity.APIKey, error) {
+	key, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, apiKeyError(id, err)
+	}
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := s.repo.Update(key); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"id":    key.ID,
+		"owner": key.Owner,
+	}).Info("API key revoked")
+
+	return key, nil
+}
+
+// Authenticate возвращает субъект запроса по предъявленному ключу.
+// Без ключа клиент анонимен и получает роль viewer, если чтение без ключа
+// разрешено; при выключенной аутентификации клиент получает роль admin.
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*auth.Principal, error) {
+	if !s.config.Enabled {
+		return &auth.Principal{Anonymous: true, Role: auth.RoleAdmin}, nil
+	}
+	if secret == "" {
+		p := &auth.Principal{Anonymous: true}
+		if s.config.AnonymousRead {
+			p.Role = auth.RoleViewer
+		}
+		return p, nil
+	}
+
+	key, err := s.repo.GetByHash(auth.HashKey(secret))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.Unauthorized("invalid_api_key", "API key is not valid", nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.Active(now) {
+		s.logger.WithFields(logrus.Fields{
+			"id":    key.ID,
+			"owner": key.Owner,
+		}).Warn("Rejected inactive API key")
+		return nil, apperror.Unauthorized("invalid_api_key", "API key is expired or revoked", nil)
+	}
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		// ошибка учёта использования не должна отклонять запрос
+		_ = s.repo.TouchLastUsed(key.ID, now)
+	}
+
+	return &auth.Principal{
+		KeyID: key.ID,
+		Name:  key.Name,
+		Owner: key.Owner,
+		Role:  auth.Role(key.Role),
+	}, nil
+}
+
+func apiKeyError(id uint, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.NotFound("api_key_not_found", fmt.Sprintf("API key %d not found", id), err)
+	}
+	return err
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
+	"github.com/sirupsen/logrus"
+)
+
+// auditFields дополняет поля журнала субъектом запроса, чтобы изменение
+// библиотеки можно было связать с API-ключом или пользователем провайдера
+// идентификации
+func auditFields(ctx context.Context, fields logrus.Fields) logrus.Fields {
+	p, ok := auth.FromContext(ctx)
+	if !ok || p.Anonymous {
+		return fields
+	}
+
+	if p.KeyID != 0 {
+		fields["api_key_id"] = p.KeyID
+		fields["owner"] = p.Owner
+	}
+	if p.Subject != "" {
+		fields["subject"] = p.Subject
+		if issuer, ok := p.Claims["iss"].(string); ok {
+			fields["issuer"] = issuer
+		}
+	}
+	return fields
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultJWKSRefresh  = time.Hour
+	defaultJWTClockSkew = 30 * time.Second
+)
+
+// AuthService определяет субъект запроса по API-ключу или JWT провайдера
+// идентификации. Токены проверяются, только если JWT включены в настройках.
+type AuthService struct {
+	keys    *APIKeyService
+	tokens  *auth.TokenVerifier
+	enabled bool
+	logger  *logrus.Logger
+}
+
+func NewAuthService(keys *APIKeyService, cfg *config.Config, log *logrus.Logger) (*AuthService, error) {
+	s := &AuthService{
+		keys:    keys,
+		enabled: cfg.Auth.Enabled,
+		logger:  log,
+	}
+	if !cfg.Auth.JWT.Enabled {
+		return s, nil
+	}
+
+	jwtCfg := cfg.Auth.JWT
+	if jwtCfg.JWKSURL == "" && jwtCfg.JWKSFile == "" {
+		return nil, errors.New("jwt requires jwks_url or jwks_file")
+	}
+	refresh, err := parseDuration(jwtCfg.RefreshInterval, defaultJWKSRefresh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt refresh_interval: %w", err)
+	}
+	skew, err := parseDuration(jwtCfg.ClockSkew, defaultJWTClockSkew)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt clock_skew: %w", err)
+	}
+	if jwtCfg.DefaultRole != "" && !auth.IsRole(jwtCfg.DefaultRole) {
+		return nil, fmt.Errorf("invalid jwt default_role %q", jwtCfg.DefaultRole)
+	}
+
+	s.tokens = auth.NewTokenVerifier(auth.NewJWKS(jwtCfg.JWKSURL, jwtCfg.JWKSFile, refresh), auth.TokenOptions{
+		Issuer:      jwtCfg.Issuer,
+		Audience:    jwtCfg.Audience,
+		ClockSkew:   skew,
+		RoleClaim:   jwtCfg.RoleClaim,
+		DefaultRole: auth.Role(jwtCfg.DefaultRole),
+	})
+	return s, nil
+}
+
+// Authenticate возвращает субъект запроса по API-ключу или JWT
+func (s *AuthService) Authenticate(ctx context.Context, credential string) (*auth.Principal, error) {
+	if !s.enabled || s.tokens == nil || !auth.IsJWT(credential) {
+		return s.keys.Authenticate(ctx, credential)
+	}
+
+	principal, err := s.tokens.Verify(ctx, credential)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Warn("Rejected bearer token")
+		return nil, err
+	}
+	return principal, nil
+}
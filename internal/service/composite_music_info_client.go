@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FieldReleaseDate = "release_date"
+	FieldText        = "text"
+	FieldLink        = "link"
+
+	musicInfoModeParallel = "parallel"
+)
+
+var songInfoFields = []string{FieldReleaseDate, FieldText, FieldLink}
+
+type musicInfoProvider struct {
+	name   string
+	client MusicInfoClient
+}
+
+type providerResult struct {
+	song *entity.Song
+	err  error
+}
+
+// compositeMusicInfoClient опрашивает несколько провайдеров и собирает
+// итоговую песню по полям согласно приоритетам
+type compositeMusicInfoClient struct {
+	providers  []musicInfoProvider
+	precedence map[string][]string
+	parallel   bool
+	logger     *logrus.Logger
+}
+
+func newCompositeMusicInfoClient(cfg config.MusicInfo, httpClient *http.Client, log *logrus.Logger) (*compositeMusicInfoClient, error) {
+	configured := make([]config.MusicInfoProvider, len(cfg.Providers))
+	copy(configured, cfg.Providers)
+	sort.SliceStable(configured, func(i, j int) bool {
+		return configured[i].Priority < configured[j].Priority
+	})
+
+	providers := make([]musicInfoProvider, 0, len(configured))
+	for _, p := range configured {
+		client, err := newMusicInfoClient(p.Name, p.URL, p.APIKey, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, musicInfoProvider{
+			name:   p.Name,
+			client: client,
+		})
+	}
+
+	return &compositeMusicInfoClient{
+		providers:  providers,
+		precedence: cfg.FieldPrecedence,
+		parallel:   cfg.Mode == musicInfoModeParallel,
+		logger:     log,
+	}, nil
+}
+
+func (c *compositeMusicInfoClient) GetSongInfo(ctx context.Context, group, title string) (*entity.Song, error) {
+	fetch := c.sequentialFetcher(ctx, group, title)
+	if c.parallel {
+		fetch = c.parallelFetcher(ctx, group, title)
+	}
+
+	song := &entity.Song{Provenance: make(map[string]entity.FieldProvenance)}
+	for _, field := range songInfoFields {
+		for _, name := range c.order(field) {
+			res := fetch(name)
+			if res.err != nil || !hasField(res.song, field) {
+				continue
+			}
+			setField(song, res.song, field)
+			song.Provenance[field] = res.song.Provenance[field]
+			break
+		}
+	}
+
+	if len(song.Provenance) == 0 {
+		var errs []error
+		for _, p := range c.providers {
+			if res := fetch(p.name); res.err != nil {
+				errs = append(errs, res.err)
+			}
+		}
+		if len(errs) == 0 {
+			return nil, fmt.Errorf("no provider returned song info: %w", ErrProviderNotFound)
+		}
+		return nil, fmt.Errorf("no provider returned song info: %w", errors.Join(errs...))
+	}
+
+	return song, nil
+}
+
+// order возвращает порядок провайдеров для поля: сначала указанные
+// в field_precedence, затем остальные по приоритету
+func (c *compositeMusicInfoClient) order(field string) []string {
+	names := make([]string, 0, len(c.providers))
+	seen := make(map[string]bool, len(c.providers))
+	for _, name := range c.precedence[field] {
+		if c.provider(name) != nil && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	for _, p := range c.providers {
+		if !seen[p.name] {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+func (c *compositeMusicInfoClient) provider(name string) *musicInfoProvider {
+	for i := range c.providers {
+		if c.providers[i].name == name {
+			return &c.providers[i]
+		}
+	}
+	return nil
+}
+
+func (c *compositeMusicInfoClient) query(ctx context.Context, p *musicInfoProvider, group, title string) providerResult {
+	song, err := p.client.GetSongInfo(ctx, group, title)
+	if err != nil {
+		c.logger.WithFields(logrus.Fields{
+			"error":    err,
+			"provider": p.name,
+			"group":    group,
+			"title":    title,
+		}).Warn("Music info provider failed")
+	}
+	return providerResult{song: song, err: err}
+}
+
+// sequentialFetcher опрашивает провайдера только при первом обращении к нему
+func (c *compositeMusicInfoClient) sequentialFetcher(ctx context.Context, group, title string) func(string) providerResult {
+	results := make(map[string]providerResult)
+	return func(name string) providerResult {
+		if res, ok := results[name]; ok {
+			return res
+		}
+		res := c.query(ctx, c.provider(name), group, title)
+		results[name] = res
+		return res
+	}
+}
+
+// parallelFetcher опрашивает всех провайдеров сразу
+func (c *compositeMusicInfoClient) parallelFetcher(ctx context.Context, group, title string) func(string) providerResult {
+	results := make(map[string]providerResult, len(c.providers))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for i := range c.providers {
+		p := &c.providers[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := c.query(ctx, p, group, title)
+			mu.Lock()
+			results[p.name] = res
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return func(name string) providerResult {
+		return results[name]
+	}
+}
+
+func isSongInfoField(field string) bool {
+	for _, f := range songInfoFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func hasField(song *entity.Song, field string) bool {
+	if song == nil {
+		return false
+	}
+	switch field {
+	case FieldReleaseDate:
+		return !song.ReleaseDate.IsZero()
+	case FieldText:
+		return song.Text != ""
+	case FieldLink:
+		return song.Link != ""
+	}
+	return false
+}
+
+func setField(dst, src *entity.Song, field string) {
+	switch field {
+	case FieldReleaseDate:
+		dst.ReleaseDate = src.ReleaseDate
+	case FieldText:
+		dst.Text = src.Text
+	case FieldLink:
+		dst.Link = src.Link
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeProvider возвращает заданные поля песни от имени провайдера name
+type fakeProvider struct {
+	name  string
+	song  *entity.Song
+	err   error
+	mu    sync.Mutex
+	calls int
+}
+
+func (p *fakeProvider) GetSongInfo(ctx context.Context, group, title string) (*entity.Song, error) {
+	p.mu.Lock()
+	p.calls++
+	p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	song := *p.song
+	song.Provenance = nil
+	for _, field := range songInfoFields {
+		if hasField(&song, field) {
+			song.SetSource(field, p.name, time.Now())
+		}
+	}
+	return &song, nil
+}
+
+func newTestComposite(parallel bool, precedence map[string][]string, providers ...*fakeProvider) *compositeMusicInfoClient {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	c := &compositeMusicInfoClient{precedence: precedence, parallel: parallel, logger: log}
+	for _, p := range providers {
+		c.providers = append(c.providers, musicInfoProvider{name: p.name, client: p})
+	}
+	return c
+}
+
+var testReleaseDate = time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+
+func TestCompositeMusicInfoClientMerge(t *testing.T) {
+	full := &entity.Song{ReleaseDate: testReleaseDate, Text: "full text", Link: "https://a.example/full"}
+	textOnly := &entity.Song{Text: "text from a"}
+	dateAndLink := &entity.Song{ReleaseDate: testReleaseDate, Link: "https://b.example/link"}
+	unavailable := &ProviderError{Provider: "a", Kind: ErrProviderUnavailable}
+
+	type provider struct {
+		song *entity.Song
+		err  error
+	}
+	tests := []struct {
+		name       string
+		a, b       provider
+		precedence map[string][]string
+		want       entity.Song
+		sources    map[string]string
+		bCalled    bool
+	}{
+		{
+			name:    "первый провайдер отдал все поля",
+			a:       provider{song: full},
+			b:       provider{song: dateAndLink},
+			want:    entity.Song{ReleaseDate: testReleaseDate, Text: "full text", Link: "https://a.example/full"},
+			sources: map[string]string{FieldReleaseDate: "a", FieldText: "a", FieldLink: "a"},
+		},
+		{
+			name:    "недостающие поля берутся у следующего",
+			a:       provider{song: textOnly},
+			b:       provider{song: dateAndLink},
+			want:    entity.Song{ReleaseDate: testReleaseDate, Text: "text from a", Link: "https://b.example/link"},
+			sources: map[string]string{FieldReleaseDate: "b", FieldText: "a", FieldLink: "b"},
+			bCalled: true,
+		},
+		{
+			name:       "field_precedence меняет порядок для поля",
+			a:          provider{song: full},
+			b:          provider{song: dateAndLink},
+			precedence: map[string][]string{FieldLink: {"b"}},
+			want:       entity.Song{ReleaseDate: testReleaseDate, Text: "full text", Link: "https://b.example/link"},
+			sources:    map[string]string{FieldReleaseDate: "a", FieldText: "a", FieldLink: "b"},
+			bCalled:    true,
+		},
+		{
+			name:       "неизвестный провайдер в field_precedence пропускается",
+			a:          provider{song: full},
+			b:          provider{song: dateAndLink},
+			precedence: map[string][]string{FieldText: {"missing"}},
+			want:       entity.Song{ReleaseDate: testReleaseDate, Text: "full text", Link: "https://a.example/full"},
+			sources:    map[string]string{FieldReleaseDate: "a", FieldText: "a", FieldLink: "a"},
+		},
+		{
+			name:    "сбой первого провайдера",
+			a:       provider{err: unavailable},
+			b:       provider{song: dateAndLink},
+			want:    entity.Song{ReleaseDate: testReleaseDate, Link: "https://b.example/link"},
+			sources: map[string]string{FieldReleaseDate: "b", FieldLink: "b"},
+			bCalled: true,
+		},
+	}
+	for _, tt := range tests {
+		for _, parallel := range []bool{false, true} {
+			a := &fakeProvider{name: "a", song: tt.a.song, err: tt.a.err}
+			b := &fakeProvider{name: "b", song: tt.b.song, err: tt.b.err}
+			c := newTestComposite(parallel, tt.precedence, a, b)
+
+			got, err := c.GetSongInfo(context.Background(), "Muse", "Supermassive Black Hole")
+			if err != nil {
+				t.Errorf("%s (parallel=%v): GetSongInfo() error = %v", tt.name, parallel, err)
+				continue
+			}
+			if !got.ReleaseDate.Equal(tt.want.ReleaseDate) || got.Text != tt.want.Text || got.Link != tt.want.Link {
+				t.Errorf("%s (parallel=%v): GetSongInfo() = {%v, %q, %q}, want {%v, %q, %q}", tt.name, parallel,
+					got.ReleaseDate, got.Text, got.Link, tt.want.ReleaseDate, tt.want.Text, tt.want.Link)
+			}
+			sources := make(map[string]string, len(got.Provenance))
+			for field, p := range got.Provenance {
+				sources[field] = p.Source
+			}
+			if !reflect.DeepEqual(sources, tt.sources) {
+				t.Errorf("%s (parallel=%v): sources = %v, want %v", tt.name, parallel, sources, tt.sources)
+			}
+			// в параллельном режиме опрашиваются все, в последовательном — только нужные
+			if wantB := tt.bCalled || parallel; (b.calls > 0) != wantB || a.calls != 1 || b.calls > 1 {
+				t.Errorf("%s (parallel=%v): calls a=%d b=%d, want a=1 b called=%v", tt.name, parallel, a.calls, b.calls, wantB)
+			}
+		}
+	}
+}
+
+func TestCompositeMusicInfoClientNoInfo(t *testing.T) {
+	unavailable := &ProviderError{Provider: "a", Kind: ErrProviderUnavailable}
+	notFound := &ProviderError{Provider: "b", Kind: ErrProviderNotFound}
+
+	tests := []struct {
+		name   string
+		errA   error
+		errB   error
+		wantIs []error
+	}{
+		{"все провайдеры без полей", nil, nil, []error{ErrProviderNotFound}},
+		{"все провайдеры отказали", unavailable, notFound, []error{ErrProviderUnavailable, ErrProviderNotFound}},
+	}
+	for _, tt := range tests {
+		a := &fakeProvider{name: "a", song: &entity.Song{}, err: tt.errA}
+		b := &fakeProvider{name: "b", song: &entity.Song{}, err: tt.errB}
+		_, err := newTestComposite(false, nil, a, b).GetSongInfo(context.Background(), "Muse", "Uprising")
+		if err == nil {
+			t.Errorf("%s: GetSongInfo() error = nil", tt.name)
+			continue
+		}
+		for _, target := range tt.wantIs {
+			if !errors.Is(err, target) {
+				t.Errorf("%s: GetSongInfo() error = %v, want %v", tt.name, err, target)
+			}
+		}
+	}
+}
+
+func TestNewCompositeMusicInfoClientOrder(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	c, err := newCompositeMusicInfoClient(config.MusicInfo{
+		Providers: []config.MusicInfoProvider{
+			{Name: "c", URL: "http://c.example", Priority: 3},
+			{Name: "a", URL: "http://a.example", Priority: 1},
+			{Name: "b", URL: "http://b.example", Priority: 2},
+		},
+		FieldPrecedence: map[string][]string{FieldText: {"c", "missing", "c"}},
+	}, &http.Client{}, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		field string
+		want  []string
+	}{
+		{FieldReleaseDate, []string{"a", "b", "c"}},
+		{FieldText, []string{"c", "a", "b"}},
+	}
+	for _, tt := range tests {
+		if got := c.order(tt.field); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("order(%q) = %v, want %v", tt.field, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/lyrics"
+	"github.com/DusmatzodaQurbonli/song-library/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// LyricsPosition — строки вокруг позиции воспроизведения.
+// Word — индекс звучащего слова текущей строки или -1.
+type LyricsPosition struct {
+	Previous *lyrics.Line
+	Current  *lyrics.Line
+	Next     *lyrics.Line
+	Index    int
+	Word     int
+}
+
+type LyricsService struct {
+	songs  *repository.SongRepository
+	lines  *repository.LyricRepository
+	logger *logrus.Logger
+}
+
+func NewLyricsService(songs *repository.SongRepository, lines *repository.LyricRepository, log *logrus.Logger) *LyricsService {
+	return &LyricsService{
+		songs:  songs,
+		lines:  lines,
+		logger: log,
+	}
+}
+
+// ResolveSongID возвращает внутренний ID песни по числовому ID или публичному UUID
+func (s *LyricsService) ResolveSongID(ctx context.Context, ref string) (uint, error) {
+	return resolveSongID(s.songs, ref)
+}
+
+// GetTiming возвращает песню и её строки с отметками времени
+func (s *LyricsService) GetTiming(ctx context.Context, id uint) (*entity.Song, []lyrics.Line, error) {
+	song, err := s.songs.GetByID(id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to get song by ID")
+		return nil, nil, songError(id, err)
+	}
+
+	stored, err := s.lines.GetBySong(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return song, fromLyricLines(stored), nil
+}
+
+// GetTimings возвращает разметку нескольких песен по их ID.
+// Песни без разметки в результат не попадают.
+func (s *LyricsService) GetTimings(ctx context.Context, ids []uint) (map[uint][]lyrics.Line, error) {
+	timings := make(map[uint][]lyrics.Line)
+	if len(ids) == 0 {
+		return timings, nil
+	}
+
+	stored, err := s.lines.GetBySongs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	bySong := make(map[uint][]entity.LyricLine)
+	for _, line := range stored {
+		bySong[line.SongID] = append(bySong[line.SongID], line)
+	}
+	for id, lines := range bySong {
+		timings[id] = fromLyricLines(lines)
+	}
+	return timings, nil
+}
+
+// GetStanzas возвращает песню и её текст, разобранный на строфы с ролями
+func (s *LyricsService) GetStanzas(ctx context.Context, id uint) (*entity.Song, []lyrics.Stanza, error) {
+	song, err := s.songs.GetByID(id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to get song by ID")
+		return nil, nil, songError(id, err)
+	}
+
+	stanzas := lyrics.ParseStanzas(song.Text)
+	s.logger.WithFields(logrus.Fields{
+		"id":      id,
+		"stanzas": len(stanzas),
+	}).Info("Song stanzas retrieved successfully")
+
+	return song, stanzas, nil
+}
+
+// SetTiming проверяет и сохраняет разметку песни, заменяя прежнюю
+func (s *LyricsService) SetTiming(ctx context.Context, id uint, lines []lyrics.Line) error {
+	if err := lyrics.Validate(lines); err != nil {
+		return timingAppError(err)
+	}
+
+	if _, err := s.songs.GetByID(id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to get song by ID")
+		return songError(id, err)
+	}
+
+	if err := s.lines.Replace(id, toLyricLines(lines)); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(auditFields(ctx, logrus.Fields{
+		"id":    id,
+		"lines": len(lines),
+	})).Info("Song timing updated successfully")
+
+	return nil
+}
+
+// ImportLRC разбирает LRC и сохраняет его как разметку песни
+func (s *LyricsService) ImportLRC(ctx context.Context, id uint, src string) ([]lyrics.Line, error) {
+	lines, err := lyrics.ParseLRC(src)
+	if err != nil {
+		return nil, apperror.Validation("invalid_lrc", err.Error(), err)
+	}
+	if len(lines) == 0 {
+		return nil, apperror.Validation("invalid_lrc", "LRC contains no timed lines", nil)
+	}
+	if err := s.SetTiming(ctx, id, lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// DeleteTiming удаляет разметку песни
+func (s *LyricsService) DeleteTiming(ctx context.Context, id uint) error {
+	if _, err := s.songs.GetByID(id); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to get song by ID")
+		return songError(id, err)
+	}
+
+	deleted, err := s.lines.DeleteBySong(id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return timingNotFound(id)
+	}
+
+	s.logger.WithFields(auditFields(ctx, logrus.Fields{
+		"id":    id,
+		"lines": deleted,
+	})).Info("Song timing deleted successfully")
+
+	return nil
+}
+
+// LineAt находит текущую, предыдущую и следующую строки для позиции t
+func (s *LyricsService) LineAt(ctx context.Context, id uint, t time.Duration) (*LyricsPosition, error) {
+	_, lines, err := s.GetTiming(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, timingNotFound(id)
+	}
+
+	i := lyrics.At(lines, t)
+	position := &LyricsPosition{Index: i, Word: -1}
+	if i >= 0 {
+		position.Current = &lines[i]
+		position.Word = lyrics.WordAt(lines[i], t)
+	}
+	if i >= 1 {
+		position.Previous = &lines[i-1]
+	}
+	if i+1 < len(lines) {
+		position.Next = &lines[i+1]
+	}
+
+	return position, nil
+}
+
+func timingNotFound(id uint) error {
+	return apperror.NotFound("timing_not_found", fmt.Sprintf("Song %d has no synced lyrics", id), nil)
+}
+
+// timingAppError переводит ошибку порядка отметок в ошибку валидации поля
+func timingAppError(err error) error {
+	var timingErr *lyrics.TimingError
+	if !errors.As(err, &timingErr) {
+		return err
+	}
+	field := fmt.Sprintf("lines[%d].start", timingErr.Line)
+	if timingErr.Word >= 0 {
+		field = fmt.Sprintf("lines[%d].words[%d].start", timingErr.Line, timingErr.Word)
+	}
+	return apperror.InvalidFields([]apperror.FieldError{{Field: field, Reason: timingErr.Reason}}, err)
+}
+
+func toLyricLines(lines []lyrics.Line) []entity.LyricLine {
+	stored := make([]entity.LyricLine, 0, len(lines))
+	for _, line := range lines {
+		var words []entity.LyricWord
+		for _, word := range line.Words {
+			words = append(words, entity.LyricWord{StartMS: word.Start.Milliseconds(), Text: word.Text})
+		}
+		stored = append(stored, entity.LyricLine{
+			StartMS: line.Start.Milliseconds(),
+			Text:    line.Text,
+			Words:   words,
+		})
+	}
+	return stored
+}
+
+func fromLyricLines(stored []entity.LyricLine) []lyrics.Line {
+	lines := make([]lyrics.Line, 0, len(stored))
+	for _, line := range stored {
+		var words []lyrics.Word
+		for _, word := range line.Words {
+			words = append(words, lyrics.Word{Start: time.Duration(word.StartMS) * time.Millisecond, Text: word.Text})
+		}
+		lines = append(lines, lyrics.Line{
+			Start: time.Duration(line.StartMS) * time.Millisecond,
+			Text:  line.Text,
+			Words: words,
+		})
+	}
+	return lines
+}
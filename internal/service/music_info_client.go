@@ -2,12 +2,13 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/DusmatzodaQurbonli/song-library/internal/config"
 	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
-	"io"
+	"github.com/DusmatzodaQurbonli/song-library/internal/musicinfo"
+	"github.com/sirupsen/logrus"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -15,54 +16,100 @@ type MusicInfoClient interface {
 	GetSongInfo(ctx context.Context, group, title string) (*entity.Song, error)
 }
 
+// defaultProviderName используется как источник полей для music_info_api
+const defaultProviderName = "music_info_api"
+
+// defaultProviderTimeout ограничивает запрос к провайдеру, если таймаут не задан
+const defaultProviderTimeout = 10 * time.Second
+
 type musicInfoClient struct {
-	baseURL string
+	name   string
+	client musicinfo.ClientWithResponsesInterface
 }
 
-func NewMusicInfoClient(cfg *config.Config) MusicInfoClient {
-	return &musicInfoClient{baseURL: cfg.MusicInfoAPI}
-}
+// NewMusicInfoClient возвращает клиент для единственного music_info_api
+// либо составной клиент, если в конфигурации задан реестр провайдеров
+func NewMusicInfoClient(cfg *config.Config, log *logrus.Logger) (MusicInfoClient, error) {
+	timeout, err := parseDuration(cfg.MusicInfo.Timeout, defaultProviderTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid music info timeout: %w", err)
+	}
+	httpClient := &http.Client{Timeout: timeout}
 
-func (c *musicInfoClient) GetSongInfo(ctx context.Context, group, title string) (*entity.Song, error) {
-	url := fmt.Sprintf("%s/info?group=%s&song=%s", c.baseURL, group, title)
+	if len(cfg.MusicInfo.Providers) == 0 {
+		client, err := newMusicInfoClient(defaultProviderName, cfg.MusicInfoAPI, cfg.MusicInfoAPIKey, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	client, err := newCompositeMusicInfoClient(cfg.MusicInfo, httpClient, log)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	return client, nil
+}
 
-	resp, err := http.DefaultClient.Do(req)
+// apiKeyHeader — заголовок, в котором провайдеру передаётся API-ключ
+const apiKeyHeader = "X-API-Key"
+
+func newMusicInfoClient(name, baseURL, apiKey string, httpClient *http.Client) (*musicInfoClient, error) {
+	opts := []musicinfo.ClientOption{musicinfo.WithHTTPClient(httpClient)}
+	if apiKey != "" {
+		opts = append(opts, musicinfo.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set(apiKeyHeader, apiKey)
+			return nil
+		}))
+	}
+	client, err := musicinfo.NewClientWithResponses(serverURL(baseURL), opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
+		return nil, fmt.Errorf("failed to create music info client %q: %w", name, err)
 	}
-	defer resp.Body.Close()
+	return &musicInfoClient{name: name, client: client}, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
+// serverURL приводит адрес провайдера к корню API: путь /info
+// добавляет сгенерированный клиент, поэтому он отбрасывается из конфигурации
+func serverURL(baseURL string) string {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return strings.TrimSuffix(baseURL, "/info")
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (c *musicInfoClient) GetSongInfo(ctx context.Context, group, title string) (*entity.Song, error) {
+	resp, err := c.client.GetSongInfoWithResponse(ctx, &musicinfo.GetSongInfoParams{
+		Group: group,
+		Song:  title,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, classifyTransportError(c.name, fmt.Errorf("failed to perform request: %w", err))
 	}
 
-	var result struct {
-		ReleaseDate string `json:"releaseDate"`
-		Text        string `json:"text"`
-		Link        string `json:"link"`
+	if resp.StatusCode() != http.StatusOK {
+		return nil, classifyStatus(c.name, resp.HTTPResponse)
 	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+
+	detail, err := musicinfo.ParseSongDetail(resp.Body)
+	if err != nil {
+		return nil, &ProviderError{Provider: c.name, Kind: ErrProviderInvalidPayload, Err: err}
 	}
 
-	releaseDate, err := time.Parse("02.01.2006", result.ReleaseDate)
+	releaseDate, err := time.Parse(musicinfo.ReleaseDateLayout, detail.ReleaseDate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse release date: %w", err)
+		return nil, &ProviderError{Provider: c.name, Kind: ErrProviderInvalidPayload, Err: err}
 	}
 
-	return &entity.Song{
+	song := &entity.Song{
 		ReleaseDate: releaseDate,
-		Text:        result.Text,
-		Link:        result.Link,
-	}, nil
+		Text:        detail.Text,
+		Link:        detail.Link,
+	}
+	fetchedAt := time.Now()
+	for _, field := range songInfoFields {
+		if hasField(song, field) {
+			song.SetSource(field, c.name, fetchedAt)
+		}
+	}
+
+	return song, nil
 }
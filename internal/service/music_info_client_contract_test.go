@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+const specPath = "../../docs/openapi.yaml"
+
+// contractStub — локальная замена провайдера, которая проверяет каждый
+// запрос клиента и каждый свой ответ по docs/openapi.yaml
+type contractStub struct {
+	t      *testing.T
+	doc    *openapi3.T
+	router routers.Router
+
+	status int
+	body   any
+	delay  time.Duration
+
+	// query, apiKey и responseErr описывают последний обработанный запрос
+	query       url.Values
+	apiKey      string
+	responseErr error
+}
+
+func newContractStub(t *testing.T) (*contractStub, *httptest.Server) {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+	// запросы идут на адрес httptest, а не на servers из спецификации
+	doc.Servers = nil
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("failed to build router: %v", err)
+	}
+
+	stub := &contractStub{t: t, doc: doc, router: router, status: http.StatusOK}
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+	return stub, srv
+}
+
+func (s *contractStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, pathParams, err := s.router.FindRoute(r)
+	if err != nil {
+		s.t.Errorf("client called a route missing from the spec: %s %s: %v", r.Method, r.URL, err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: pathParams,
+		Route:      route,
+	}
+	if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+		s.t.Errorf("client request violates the spec: %v", err)
+	}
+	s.query = r.URL.Query()
+	s.apiKey = r.Header.Get(apiKeyHeader)
+
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	var body []byte
+	header := http.Header{}
+	if s.body != nil {
+		body, _ = json.Marshal(s.body)
+		header.Set("Content-Type", "application/json")
+	}
+
+	s.responseErr = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 s.status,
+		Header:                 header,
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+	})
+
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(s.status)
+	_, _ = w.Write(body)
+}
+
+func (s *contractStub) songDetailSchema() *openapi3.Schema {
+	return s.doc.Components.Schemas["SongDetail"].Value
+}
+
+// validDetail строит ответ из примеров схемы SongDetail
+func (s *contractStub) validDetail() map[string]any {
+	detail := make(map[string]any)
+	for name, prop := range s.songDetailSchema().Properties {
+		detail[name] = prop.Value.Example
+	}
+	return detail
+}
+
+func newContractClient(t *testing.T, url string) *musicInfoClient {
+	t.Helper()
+	client, err := newMusicInfoClient("contract", url, "", &http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestMusicInfoContract_Success(t *testing.T) {
+	stub, srv := newContractStub(t)
+	stub.body = stub.validDetail()
+
+	// music_info_api в конфигурации может содержать путь /info
+	client := newContractClient(t, srv.URL+"/info")
+	song, err := client.GetSongInfo(context.Background(), "AC/DC", "Guns N' Roses & friends")
+	if err != nil {
+		t.Fatalf("GetSongInfo: %v", err)
+	}
+	if stub.responseErr != nil {
+		t.Fatalf("stub response violates the spec: %v", stub.responseErr)
+	}
+	if stub.query.Get("group") != "AC/DC" || stub.query.Get("song") != "Guns N' Roses & friends" {
+		t.Errorf("query parameters were not escaped: %v", stub.query)
+	}
+
+	detail := stub.validDetail()
+	if song.Text != detail["text"] || song.Link != detail["link"] {
+		t.Errorf("unexpected song: text=%q link=%q", song.Text, song.Link)
+	}
+	if got := song.ReleaseDate.Format("02.01.2006"); got != detail["releaseDate"] {
+		t.Errorf("release date = %s, want %s", got, detail["releaseDate"])
+	}
+}
+
+func TestMusicInfoContract_MissingRequiredField(t *testing.T) {
+	stub, srv := newContractStub(t)
+	client := newContractClient(t, srv.URL)
+
+	for _, field := range stub.songDetailSchema().Required {
+		t.Run(field, func(t *testing.T) {
+			body := stub.validDetail()
+			delete(body, field)
+			stub.body = body
+
+			_, err := client.GetSongInfo(context.Background(), "Muse", "Supermassive Black Hole")
+			if !errors.Is(err, ErrProviderInvalidPayload) {
+				t.Errorf("expected invalid payload without required field %q, got %v", field, err)
+			}
+			if stub.responseErr == nil {
+				t.Errorf("spec accepted a response without required field %q", field)
+			}
+		})
+	}
+}
+
+func TestMusicInfoContract_MalformedReleaseDate(t *testing.T) {
+	stub, srv := newContractStub(t)
+	body := stub.validDetail()
+	body["releaseDate"] = "2006-07-16"
+	stub.body = body
+
+	client := newContractClient(t, srv.URL)
+	_, err := client.GetSongInfo(context.Background(), "Muse", "Supermassive Black Hole")
+	if !errors.Is(err, ErrProviderInvalidPayload) {
+		t.Fatalf("expected invalid payload for a release date not in 02.01.2006 format, got %v", err)
+	}
+}
+
+func TestMusicInfoContract_ErrorStatuses(t *testing.T) {
+	stub, srv := newContractStub(t)
+	client := newContractClient(t, srv.URL)
+
+	tests := []struct {
+		status int
+		kind   error
+	}{
+		{http.StatusBadRequest, ErrProviderBadRequest},
+		{http.StatusInternalServerError, ErrProviderUnavailable},
+	}
+	for _, tt := range tests {
+		status := tt.status
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			stub.status = status
+			stub.body = nil
+
+			_, err := client.GetSongInfo(context.Background(), "Muse", "Supermassive Black Hole")
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("expected %v for status %d, got %v", tt.kind, status, err)
+			}
+			if stub.responseErr != nil {
+				t.Errorf("status %d is not declared in the spec: %v", status, stub.responseErr)
+			}
+		})
+	}
+}
+
+func TestMusicInfoContract_Canceled(t *testing.T) {
+	stub, srv := newContractStub(t)
+	stub.body = stub.validDetail()
+	stub.delay = time.Second
+
+	client := newContractClient(t, srv.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := client.GetSongInfo(ctx, "Muse", "Supermassive Black Hole")
+	if !errors.Is(err, ErrProviderCanceled) || errors.Is(err, ErrProviderUnavailable) {
+		t.Fatalf("expected provider request canceled, got %v", err)
+	}
+}
+
+func TestMusicInfoContract_ClientTimeout(t *testing.T) {
+	stub, srv := newContractStub(t)
+	stub.body = stub.validDetail()
+	stub.delay = time.Second
+
+	client, err := newMusicInfoClient("contract", srv.URL, "", &http.Client{Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.GetSongInfo(context.Background(), "Muse", "Supermassive Black Hole")
+	if !errors.Is(err, ErrProviderTimeout) {
+		t.Fatalf("expected provider timeout without a context deadline, got %v", err)
+	}
+}
+
+func TestMusicInfoContract_APIKey(t *testing.T) {
+	stub, srv := newContractStub(t)
+	stub.body = stub.validDetail()
+
+	for _, key := range []string{"", "sl_provider_key"} {
+		client, err := newMusicInfoClient("contract", srv.URL, key, &http.Client{Timeout: 5 * time.Second})
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		if _, err := client.GetSongInfo(context.Background(), "Muse", "Supermassive Black Hole"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stub.apiKey != key {
+			t.Errorf("%s = %q, want %q", apiKeyHeader, stub.apiKey, key)
+		}
+	}
+}
+
+func TestMusicInfoContract_SlowResponse(t *testing.T) {
+	stub, srv := newContractStub(t)
+	stub.body = stub.validDetail()
+	stub.delay = time.Second
+
+	client := newContractClient(t, srv.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetSongInfo(ctx, "Muse", "Supermassive Black Hole")
+	if !errors.Is(err, ErrProviderTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected provider timeout, got %v", err)
+	}
+}
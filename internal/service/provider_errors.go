@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+)
+
+// Ошибки провайдера метаданных. Проверяются через errors.Is.
+var (
+	ErrProviderNotFound       = errors.New("song not found at provider")
+	ErrProviderInvalidPayload = errors.New("invalid provider payload")
+	ErrProviderUnavailable    = errors.New("provider unavailable")
+	ErrProviderRateLimited    = errors.New("provider rate limited")
+	ErrProviderTimeout        = errors.New("provider timeout")
+	ErrProviderBadRequest     = errors.New("provider rejected request")
+	ErrProviderCanceled       = errors.New("provider request canceled")
+)
+
+// ProviderError описывает сбой конкретного провайдера.
+// Kind — одна из ошибок ErrProvider*, Err — исходная причина.
+type ProviderError struct {
+	Provider   string
+	Kind       error
+	StatusCode int
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *ProviderError) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Provider, e.Kind)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status %d)", e.StatusCode)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ProviderError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// classifyTransportError определяет вид ошибки, возникшей до получения ответа.
+// Отмена контекста означает, что запрос больше не нужен, а не сбой провайдера.
+func classifyTransportError(provider string, err error) error {
+	kind := ErrProviderUnavailable
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		kind = ErrProviderCanceled
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		kind = ErrProviderTimeout
+	}
+	return &ProviderError{Provider: provider, Kind: kind, Err: err}
+}
+
+// classifyStatus определяет вид ошибки по статусу ответа провайдера
+func classifyStatus(provider string, resp *http.Response) error {
+	err := &ProviderError{
+		Provider:   provider,
+		Kind:       ErrProviderUnavailable,
+		StatusCode: resp.StatusCode,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		err.Kind = ErrProviderBadRequest
+	case http.StatusNotFound:
+		err.Kind = ErrProviderNotFound
+	case http.StatusTooManyRequests:
+		err.Kind = ErrProviderRateLimited
+		if seconds, parseErr := strconv.Atoi(resp.Header.Get("Retry-After")); parseErr == nil {
+			err.RetryAfter = time.Duration(seconds) * time.Second
+		}
+	case http.StatusGatewayTimeout:
+		err.Kind = ErrProviderTimeout
+	}
+
+	return err
+}
+
+// providerErrorStatuses сопоставляет ошибки провайдера статусам и кодам ответа.
+// Порядок важен: при нескольких причинах выбирается первая совпавшая.
+var providerErrorStatuses = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{ErrProviderTimeout, http.StatusGatewayTimeout, "provider_timeout"},
+	{ErrProviderRateLimited, http.StatusServiceUnavailable, "provider_rate_limited"},
+	{ErrProviderUnavailable, http.StatusBadGateway, "provider_unavailable"},
+	{ErrProviderBadRequest, http.StatusInternalServerError, "provider_bad_request"},
+	{ErrProviderInvalidPayload, http.StatusUnprocessableEntity, "provider_invalid_payload"},
+	{ErrProviderNotFound, http.StatusNotFound, "provider_not_found"},
+}
+
+// providerAppError переводит сбой провайдера в доменную ошибку. Отмена
+// запроса возвращается как есть: клиент уже не ждёт ответа.
+func providerAppError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	for _, m := range providerErrorStatuses {
+		if !errors.Is(err, m.kind) {
+			continue
+		}
+
+		switch m.kind {
+		case ErrProviderNotFound:
+			return apperror.NotFound(m.code, "Song not found at music info provider", err)
+		case ErrProviderBadRequest:
+			// провайдер отверг запрос, который сформировал сервис
+			return apperror.Internal(m.code, "Music info provider rejected the request", err)
+		}
+
+		appErr := apperror.Unavailable(m.code, "Music info provider failed: "+m.kind.Error(), err).WithStatus(m.status)
+		var providerErr *ProviderError
+		if errors.As(err, &providerErr) {
+			appErr.RetryAfter = providerErr.RetryAfter
+		}
+		return appErr
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+)
+
+func TestProviderAppError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		code       string
+		retryAfter time.Duration
+	}{
+		{"не найдена", &ProviderError{Provider: "a", Kind: ErrProviderNotFound}, http.StatusNotFound, "provider_not_found", 0},
+		{"неверный запрос", &ProviderError{Provider: "a", Kind: ErrProviderBadRequest, StatusCode: 400},
+			http.StatusInternalServerError, "provider_bad_request", 0},
+		{"недоступен", &ProviderError{Provider: "a", Kind: ErrProviderUnavailable, StatusCode: 503},
+			http.StatusBadGateway, "provider_unavailable", 0},
+		{"таймаут", &ProviderError{Provider: "a", Kind: ErrProviderTimeout}, http.StatusGatewayTimeout, "provider_timeout", 0},
+		{"лимит", &ProviderError{Provider: "a", Kind: ErrProviderRateLimited, RetryAfter: 7 * time.Second},
+			http.StatusServiceUnavailable, "provider_rate_limited", 7 * time.Second},
+		{"некорректный ответ", &ProviderError{Provider: "a", Kind: ErrProviderInvalidPayload},
+			http.StatusUnprocessableEntity, "provider_invalid_payload", 0},
+		// составной клиент: недоступность важнее отказа в запросе
+		{"несколько провайдеров", fmt.Errorf("no provider returned song info: %w", errors.Join(
+			&ProviderError{Provider: "a", Kind: ErrProviderBadRequest},
+			&ProviderError{Provider: "b", Kind: ErrProviderUnavailable},
+		)), http.StatusBadGateway, "provider_unavailable", 0},
+	}
+	for _, tt := range tests {
+		appErr, ok := apperror.As(providerAppError(tt.err))
+		if !ok {
+			t.Errorf("%s: providerAppError() is not an apperror", tt.name)
+			continue
+		}
+		if appErr.HTTPStatus() != tt.status || appErr.Code != tt.code || appErr.RetryAfter != tt.retryAfter {
+			t.Errorf("%s: providerAppError() = {%d, %s, %v}, want {%d, %s, %v}",
+				tt.name, appErr.HTTPStatus(), appErr.Code, appErr.RetryAfter, tt.status, tt.code, tt.retryAfter)
+		}
+	}
+}
+
+func TestProviderAppErrorCanceled(t *testing.T) {
+	err := classifyTransportError("a", fmt.Errorf("failed to perform request: %w", context.Canceled))
+	got := providerAppError(err)
+	if _, ok := apperror.As(got); ok {
+		t.Errorf("providerAppError(canceled) = %v, want a plain cancellation", got)
+	}
+	if !errors.Is(got, context.Canceled) || !errors.Is(got, ErrProviderCanceled) {
+		t.Errorf("providerAppError(canceled) = %v, want context.Canceled", got)
+	}
+}
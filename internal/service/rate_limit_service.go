@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/ratelimit"
+	"github.com/DusmatzodaQurbonli/song-library/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// RateLimitBudget — бюджет, из которого списываются запросы клиента
+type RateLimitBudget string
+
+const (
+	BudgetRead  RateLimitBudget = "read"
+	BudgetWrite RateLimitBudget = "write"
+
+	// BudgetFailedAuth расходуют попытки с недействительными учётными
+	// данными. Они списываются с адреса клиента до того, как известен субъект.
+	BudgetFailedAuth = BudgetWrite
+)
+
+const (
+	RateLimitStoreMemory   = "memory"
+	RateLimitStorePostgres = "postgres"
+
+	defaultRateLimitPeriod = time.Minute
+)
+
+// BudgetFor возвращает бюджет операции: чтение расходует бюджет чтения,
+// всё остальное — бюджет записи
+func BudgetFor(perm auth.Permission) RateLimitBudget {
+	if perm == auth.PermRead {
+		return BudgetRead
+	}
+	return BudgetWrite
+}
+
+// RateLimitService ограничивает частоту запросов каждого клиента
+type RateLimitService struct {
+	limiter ratelimit.Limiter
+	limits  map[RateLimitBudget]ratelimit.Limit
+	enabled bool
+	logger  *logrus.Logger
+}
+
+func NewRateLimitService(cfg *config.Config, buckets *repository.RateLimitRepository, log *logrus.Logger) (*RateLimitService, error) {
+	s := &RateLimitService{
+		enabled: cfg.RateLimit.Enabled,
+		logger:  log,
+	}
+	if !s.enabled {
+		return s, nil
+	}
+
+	switch cfg.RateLimit.Store {
+	case "", RateLimitStoreMemory:
+		s.limiter = ratelimit.NewMemoryLimiter()
+	case RateLimitStorePostgres:
+		s.limiter = buckets
+	default:
+		return nil, fmt.Errorf("unknown rate limit store: %q", cfg.RateLimit.Store)
+	}
+
+	read, err := newLimit(cfg.RateLimit.Read)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit read budget: %w", err)
+	}
+	write, err := newLimit(cfg.RateLimit.Write)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit write budget: %w", err)
+	}
+	s.limits = map[RateLimitBudget]ratelimit.Limit{BudgetRead: read, BudgetWrite: write}
+	return s, nil
+}
+
+func newLimit(budget config.RateLimitBudget) (ratelimit.Limit, error) {
+	if budget.Requests <= 0 {
+		return ratelimit.Limit{}, fmt.Errorf("requests must be positive")
+	}
+	period, err := parseDuration(budget.Period, defaultRateLimitPeriod)
+	if err != nil {
+		return ratelimit.Limit{}, err
+	}
+	if period <= 0 {
+		return ratelimit.Limit{}, fmt.Errorf("period must be positive")
+	}
+	burst := budget.Burst
+	if burst <= 0 {
+		burst = budget.Requests
+	}
+	return ratelimit.Limit{Requests: budget.Requests, Period: period, Burst: burst}, nil
+}
+
+// RateLimitClient определяет, чей бюджет расходует запрос: API-ключа,
+// пользователя провайдера идентификации или адреса клиента addr
+func RateLimitClient(ctx context.Context, addr string) string {
+	if p, ok := auth.FromContext(ctx); ok && !p.Anonymous {
+		if p.KeyID != 0 {
+			return "key:" + strconv.FormatUint(uint64(p.KeyID), 10)
+		}
+		if p.Subject != "" {
+			return "sub:" + p.Subject
+		}
+	}
+	return "ip:" + addr
+}
+
+// Allow списывает запрос клиента из бюджета. Исчерпанный бюджет даёт
+// ошибку 429 вместе с итогом для заголовков RateLimit-*. Без итога
+// (ограничение выключено или хранилище недоступно) запрос пропускается:
+// сбой хранилища не должен останавливать API.
+func (s *RateLimitService) Allow(ctx context.Context, budget RateLimitBudget, client string) (*ratelimit.Result, error) {
+	if !s.enabled {
+		return nil, nil
+	}
+
+	result, err := s.limiter.Allow(ctx, string(budget)+":"+client, s.limits[budget])
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"budget": budget,
+			"client": client,
+		}).Warn("Rate limiter unavailable, request allowed")
+		return nil, nil
+	}
+	if !result.Allowed {
+		s.logger.WithFields(logrus.Fields{
+			"budget": budget,
+			"client": client,
+		}).Debug("Rate limit exceeded")
+		return &result, apperror.RateLimited("rate_limited",
+			fmt.Sprintf("Rate limit for %s requests exceeded", budget), result.RetryAfter)
+	}
+	return &result, nil
+}
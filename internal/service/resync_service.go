@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/musicinfo"
+	"github.com/DusmatzodaQurbonli/song-library/internal/repository"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	ResyncPolicyAuto   = "auto"
+	ResyncPolicyReview = "review"
+
+	defaultResyncInterval  = 24 * time.Hour
+	defaultResyncMaxAge    = 30 * 24 * time.Hour
+	defaultResyncBatchSize = 100
+)
+
+// FieldChange описывает расхождение одного поля с данными провайдера
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// SongResyncResult содержит результат обновления одной песни
+type SongResyncResult struct {
+	SongID  uint          `json:"song_id"`
+	Group   string        `json:"group"`
+	Title   string        `json:"title"`
+	Changes []FieldChange `json:"changes,omitempty"`
+	Applied bool          `json:"applied"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// ResyncReport содержит отчёт о прогоне обновления
+type ResyncReport struct {
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+	Policy     string             `json:"policy"`
+	Checked    int                `json:"checked"`
+	Changed    int                `json:"changed"`
+	Failed     int                `json:"failed"`
+	Songs      []SongResyncResult `json:"songs"`
+}
+
+type ResyncService struct {
+	repo       *repository.SongRepository
+	changes    *repository.SongChangeRepository
+	infoClient MusicInfoClient
+	logger     *logrus.Logger
+
+	enabled   bool
+	interval  time.Duration
+	maxAge    time.Duration
+	policy    string
+	batchSize int
+
+	mu sync.Mutex
+}
+
+func NewResyncService(
+	cfg *config.Config,
+	repo *repository.SongRepository,
+	changes *repository.SongChangeRepository,
+	client MusicInfoClient,
+	log *logrus.Logger,
+) (*ResyncService, error) {
+	interval, err := parseDuration(cfg.Resync.Interval, defaultResyncInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resync interval: %w", err)
+	}
+	maxAge, err := parseDuration(cfg.Resync.MaxAge, defaultResyncMaxAge)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resync max_age: %w", err)
+	}
+
+	policy := cfg.Resync.Policy
+	switch policy {
+	case "":
+		policy = ResyncPolicyReview
+	case ResyncPolicyAuto, ResyncPolicyReview:
+	default:
+		return nil, fmt.Errorf("unknown resync policy: %q", policy)
+	}
+
+	batchSize := cfg.Resync.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultResyncBatchSize
+	}
+
+	return &ResyncService{
+		repo:       repo,
+		changes:    changes,
+		infoClient: client,
+		logger:     log,
+		enabled:    cfg.Resync.Enabled,
+		interval:   interval,
+		maxAge:     maxAge,
+		policy:     policy,
+		batchSize:  batchSize,
+	}, nil
+}
+
+// Run периодически запускает обновление, пока не будет отменён ctx
+func (s *ResyncService) Run(ctx context.Context) {
+	if !s.enabled {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Resync(ctx, nil, 0); err != nil {
+				s.logger.WithError(err).Error("Scheduled resync failed")
+			}
+		}
+	}
+}
+
+// Resync обновляет песни, синхронизированные раньше maxAge назад.
+// Нулевой maxAge означает значение из конфигурации.
+func (s *ResyncService) Resync(ctx context.Context, filter map[string]string, maxAge time.Duration) (*ResyncReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if maxAge <= 0 {
+		maxAge = s.maxAge
+	}
+
+	report := &ResyncReport{
+		StartedAt: time.Now(),
+		Policy:    s.policy,
+		Songs:     []SongResyncResult{},
+	}
+
+	songs, err := s.repo.GetStale(filter, report.StartedAt.Add(-maxAge), s.batchSize)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"filter": filter,
+		}).Error("Failed to get songs for resync")
+		return nil, err
+	}
+
+	for i := range songs {
+		result := s.resyncSong(ctx, &songs[i])
+		report.Checked++
+		if result.Error != "" {
+			report.Failed++
+		} else if len(result.Changes) > 0 {
+			report.Changed++
+		}
+		report.Songs = append(report.Songs, result)
+	}
+	report.FinishedAt = time.Now()
+
+	s.logger.WithFields(logrus.Fields{
+		"checked": report.Checked,
+		"changed": report.Changed,
+		"failed":  report.Failed,
+		"policy":  report.Policy,
+	}).Info("Resync finished")
+
+	return report, nil
+}
+
+func (s *ResyncService) resyncSong(ctx context.Context, song *entity.Song) SongResyncResult {
+	result := SongResyncResult{
+		SongID: song.ID,
+		Group:  song.Group,
+		Title:  song.Title,
+	}
+
+	info, err := s.infoClient.GetSongInfo(ctx, song.Group, song.Title)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    song.ID,
+		}).Warn("Failed to get song info for resync")
+		result.Error = err.Error()
+		return result
+	}
+
+	now := time.Now()
+	if s.policy != ResyncPolicyAuto {
+		result.Changes = infoChanges(song, info)
+		for _, change := range result.Changes {
+			if err := s.changes.Queue(&entity.SongChange{
+				SongID:   song.ID,
+				Field:    change.Field,
+				OldValue: change.Old,
+				NewValue: change.New,
+				Source:   info.Provenance[change.Field].Source,
+			}); err != nil {
+				result.Error = err.Error()
+				return result
+			}
+		}
+	}
+
+	// Песню могли изменить, пока шёл запрос к провайдеру, поэтому блокировки
+	// и значения проверяются заново по свежей строке
+	err = s.repo.UpdateColumns(song.ID, func(fresh *entity.Song) ([]string, error) {
+		columns := []string{"synced_at"}
+		fresh.SyncedAt = &now
+		if s.policy != ResyncPolicyAuto {
+			return columns, nil
+		}
+		result.Changes = infoChanges(fresh, info)
+		for _, change := range result.Changes {
+			setField(fresh, info, change.Field)
+			fresh.SetSource(change.Field, info.Provenance[change.Field].Source, now)
+			columns = append(columns, change.Field)
+		}
+		if len(result.Changes) > 0 {
+			columns = append(columns, "provenance", "updated_at")
+		}
+		return columns, nil
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Applied = s.policy == ResyncPolicyAuto && len(result.Changes) > 0
+
+	return result
+}
+
+// infoChanges сравнивает песню с данными провайдера, пропуская
+// заблокированные поля
+func infoChanges(song, info *entity.Song) []FieldChange {
+	var changes []FieldChange
+	for _, field := range songInfoFields {
+		if !hasField(info, field) || song.IsLocked(field) {
+			continue
+		}
+		oldValue, newValue := fieldValue(song, field), fieldValue(info, field)
+		if oldValue != newValue {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	return changes
+}
+
+// GetChanges возвращает изменения из очереди с указанным статусом
+func (s *ResyncService) GetChanges(ctx context.Context, status string) ([]entity.SongChange, error) {
+	changes, err := s.changes.GetByStatus(status)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error":  err,
+			"status": status,
+		}).Error("Failed to get song changes")
+		return nil, err
+	}
+	return changes, nil
+}
+
+// ApplyChange применяет изменение из очереди к песне. Изменение отклоняется
+// как устаревшее, если поле песни изменилось после постановки в очередь.
+func (s *ResyncService) ApplyChange(ctx context.Context, id uint) error {
+	change, err := s.pendingChange(id)
+	if err != nil {
+		return err
+	}
+
+	err = s.changes.Apply(change, func(song *entity.Song) error {
+		if song.IsLocked(change.Field) {
+			return apperror.Conflict("field_locked", fmt.Sprintf("Song %d field %s is locked", song.ID, change.Field), nil)
+		}
+		if current := fieldValue(song, change.Field); current != change.OldValue {
+			return apperror.Conflict("change_stale",
+				fmt.Sprintf("Song %d field %s has changed since song change %d was queued", song.ID, change.Field, id), nil)
+		}
+		if err := setFieldValue(song, change.Field, change.NewValue); err != nil {
+			return err
+		}
+		song.SetSource(change.Field, change.Source, time.Now())
+		return nil
+	})
+	switch {
+	case errors.Is(err, repository.ErrChangeNotPending):
+		return apperror.Conflict("song_change_not_pending", fmt.Sprintf("Song change %d is no longer pending", id), err)
+	case err != nil:
+		return songError(change.SongID, err)
+	}
+
+	s.logger.WithFields(auditFields(ctx, logrus.Fields{
+		"id":      id,
+		"song_id": change.SongID,
+		"field":   change.Field,
+	})).Info("Song change applied")
+
+	return nil
+}
+
+// RejectChange отклоняет изменение из очереди
+func (s *ResyncService) RejectChange(ctx context.Context, id uint) error {
+	if _, err := s.pendingChange(id); err != nil {
+		return err
+	}
+	if err := s.changes.SetStatus(id, entity.SongChangeRejected); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(auditFields(ctx, logrus.Fields{
+		"id": id,
+	})).Info("Song change rejected")
+
+	return nil
+}
+
+func (s *ResyncService) pendingChange(id uint) (*entity.SongChange, error) {
+	change, err := s.changes.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NotFound("song_change_not_found", fmt.Sprintf("Song change %d not found", id), err)
+		}
+		return nil, err
+	}
+	if change.Status != entity.SongChangePending {
+		return nil, apperror.Conflict("song_change_not_pending", fmt.Sprintf("Song change %d is already %s", id, change.Status), nil)
+	}
+	return change, nil
+}
+
+func fieldValue(song *entity.Song, field string) string {
+	switch field {
+	case FieldReleaseDate:
+		return song.ReleaseDate.Format(musicinfo.ReleaseDateLayout)
+	case FieldText:
+		return song.Text
+	case FieldLink:
+		return song.Link
+	}
+	return ""
+}
+
+func setFieldValue(song *entity.Song, field, value string) error {
+	switch field {
+	case FieldReleaseDate:
+		releaseDate, err := time.Parse(musicinfo.ReleaseDateLayout, value)
+		if err != nil {
+			return fmt.Errorf("failed to parse release date: %w", err)
+		}
+		song.ReleaseDate = releaseDate
+	case FieldText:
+		song.Text = value
+	case FieldLink:
+		song.Link = value
+	default:
+		return apperror.Validation("unknown_field", fmt.Sprintf("Unknown song field %q", field), nil)
+	}
+	return nil
+}
+
+func parseDuration(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return time.ParseDuration(value)
+}
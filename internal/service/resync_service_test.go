@@ -0,0 +1,73 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+)
+
+func TestSetFieldValue(t *testing.T) {
+	tests := []struct {
+		field, value string
+		wantKind     apperror.Kind
+	}{
+		{FieldReleaseDate, "16.07.2006", ""},
+		{FieldText, "Ooh baby, don't you know I suffer?", ""},
+		{FieldLink, "https://www.youtube.com/watch?v=Xsp3_a-PMTw", ""},
+		{"title", "Uprising", apperror.KindValidation},
+		{"", "", apperror.KindValidation},
+	}
+	for _, tt := range tests {
+		song := &entity.Song{}
+		err := setFieldValue(song, tt.field, tt.value)
+		if tt.wantKind != "" {
+			if !apperror.IsKind(err, tt.wantKind) {
+				t.Errorf("setFieldValue(%q) error = %v, want kind %s", tt.field, err, tt.wantKind)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("setFieldValue(%q) error = %v", tt.field, err)
+			continue
+		}
+		// значение из очереди должно совпадать с тем, что читает проверка устаревания
+		if got := fieldValue(song, tt.field); got != tt.value {
+			t.Errorf("fieldValue(%q) after set = %q, want %q", tt.field, got, tt.value)
+		}
+	}
+}
+
+func TestInfoChanges(t *testing.T) {
+	info := &entity.Song{Text: "new text", Link: "https://example.com/new"}
+	tests := []struct {
+		name string
+		song entity.Song
+		want []string
+	}{
+		{"изменились текст и ссылка", entity.Song{Text: "old text", Link: "https://example.com/old"}, []string{FieldText, FieldLink}},
+		{"ссылка совпадает", entity.Song{Text: "old text", Link: "https://example.com/new"}, []string{FieldText}},
+		{
+			"текст заблокирован",
+			entity.Song{
+				Text:       "old text",
+				Link:       "https://example.com/old",
+				Provenance: map[string]entity.FieldProvenance{FieldText: {Locked: true}},
+			},
+			[]string{FieldLink},
+		},
+		// у провайдера нет даты выхода — поле не трогаем
+		{"пустое поле провайдера", entity.Song{Text: "new text", Link: "https://example.com/new"}, nil},
+	}
+	for _, tt := range tests {
+		changes := infoChanges(&tt.song, info)
+		var got []string
+		for _, change := range changes {
+			got = append(got, change.Field)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: infoChanges() fields = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
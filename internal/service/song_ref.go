@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SongRef ссылается на песню по внутреннему ID либо по публичному UUID.
+// Внутренний ID зависит от базы, публичный стабилен при её переносе и слиянии.
+type SongRef struct {
+	ID       uint
+	PublicID uuid.UUID
+}
+
+// ParseSongRef разбирает числовой ID или публичный UUID песни
+func ParseSongRef(s string) (SongRef, bool) {
+	if id, err := strconv.ParseUint(s, 10, 32); err == nil {
+		return SongRef{ID: uint(id)}, id > 0
+	}
+	if id, err := uuid.Parse(s); err == nil && id != uuid.Nil {
+		return SongRef{PublicID: id}, true
+	}
+	return SongRef{}, false
+}
+
+func (r SongRef) String() string {
+	if r.PublicID != uuid.Nil {
+		return r.PublicID.String()
+	}
+	return strconv.FormatUint(uint64(r.ID), 10)
+}
+
+func resolveSongID(repo *repository.SongRepository, s string) (uint, error) {
+	ref, ok := ParseSongRef(s)
+	if !ok {
+		return 0, apperror.InvalidFields([]apperror.FieldError{{
+			Field:  "id",
+			Reason: "must be a positive integer or a public UUID",
+		}}, nil)
+	}
+	if ref.PublicID == uuid.Nil {
+		return ref.ID, nil
+	}
+
+	id, err := repo.GetIDByPublicID(ref.PublicID)
+	if err != nil {
+		return 0, songRefError(ref, err)
+	}
+	return id, nil
+}
+
+func songRefError(ref SongRef, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.NotFound("song_not_found", fmt.Sprintf("Song %s not found", ref), err)
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestParseSongRef(t *testing.T) {
+	publicID := uuid.MustParse("3f2c1a9e-8b7d-4c6e-9f0a-1b2c3d4e5f60")
+	tests := []struct {
+		in   string
+		want SongRef
+		ok   bool
+	}{
+		{"42", SongRef{ID: 42}, true},
+		{publicID.String(), SongRef{PublicID: publicID}, true},
+		{"3F2C1A9E-8B7D-4C6E-9F0A-1B2C3D4E5F60", SongRef{PublicID: publicID}, true},
+		{"0", SongRef{}, false},
+		{"-1", SongRef{}, false},
+		{"4294967296", SongRef{}, false},
+		{uuid.Nil.String(), SongRef{}, false},
+		{"queen", SongRef{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseSongRef(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseSongRef(%q) = %+v, %v, want %+v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestOrderByRefs(t *testing.T) {
+	a := entity.Song{ID: 1, PublicID: uuid.MustParse("00000000-0000-0000-0000-00000000000a"), Title: "a"}
+	b := entity.Song{ID: 2, PublicID: uuid.MustParse("00000000-0000-0000-0000-00000000000b"), Title: "b"}
+	c := entity.Song{ID: 3, PublicID: uuid.MustParse("00000000-0000-0000-0000-00000000000c"), Title: "c"}
+	byID := func(id uint) SongRef { return SongRef{ID: id} }
+	byUUID := func(s entity.Song) SongRef { return SongRef{PublicID: s.PublicID} }
+	unknownUUID := SongRef{PublicID: uuid.MustParse("00000000-0000-0000-0000-0000000000ff")}
+
+	tests := []struct {
+		name    string
+		found   []entity.Song
+		refs    []SongRef
+		titles  []string
+		missing []SongRef
+	}{
+		{"порядок запроса, а не базы", []entity.Song{a, b, c}, []SongRef{byID(3), byID(1), byID(2)},
+			[]string{"c", "a", "b"}, []SongRef{}},
+		{"числовые ID и UUID вперемешку", []entity.Song{a, b, c}, []SongRef{byUUID(b), byID(3), byUUID(a)},
+			[]string{"b", "c", "a"}, []SongRef{}},
+		{"повторы выводятся один раз", []entity.Song{a, b}, []SongRef{byID(2), byID(1), byID(2)},
+			[]string{"b", "a"}, []SongRef{}},
+		{"одна песня по ID и по UUID", []entity.Song{a}, []SongRef{byID(1), byUUID(a)},
+			[]string{"a"}, []SongRef{}},
+		{"повтор по UUID не сдвигает порядок", []entity.Song{a, b}, []SongRef{byUUID(b), byID(1), byID(2)},
+			[]string{"b", "a"}, []SongRef{}},
+		{"ненайденные в порядке запроса", []entity.Song{b}, []SongRef{unknownUUID, byID(2), byID(9), unknownUUID},
+			[]string{"b"}, []SongRef{unknownUUID, byID(9)}},
+		{"ничего не найдено", nil, []SongRef{byID(1)}, []string{}, []SongRef{byID(1)}},
+		{"пустой запрос", nil, nil, []string{}, []SongRef{}},
+	}
+	for _, tt := range tests {
+		songs, missing := orderByRefs(tt.found, tt.refs)
+		titles := make([]string, 0, len(songs))
+		for _, s := range songs {
+			titles = append(titles, s.Title)
+		}
+		if !reflect.DeepEqual(titles, tt.titles) {
+			t.Errorf("%s: songs = %v, want %v", tt.name, titles, tt.titles)
+		}
+		if !reflect.DeepEqual(missing, tt.missing) {
+			t.Errorf("%s: missing = %v, want %v", tt.name, missing, tt.missing)
+		}
+	}
+}
@@ -2,11 +2,32 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
 	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
 	"github.com/DusmatzodaQurbonli/song-library/internal/repository"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
+// SongOrder задаёт сортировку списка песен
+type SongOrder = repository.SongOrder
+
+// Artist — исполнитель и число его песен в библиотеке
+type Artist struct {
+	Name      string
+	SongCount int64
+}
+
+// SongService управляет песнями библиотеки. Изменяющие операции сами
+// проверяют права субъекта из контекста, поэтому матрица ролей соблюдается
+// для любого вызывающего кода, а не только для маршрутов REST.
 type SongService struct {
 	repo       *repository.SongRepository
 	infoClient MusicInfoClient
@@ -23,19 +44,30 @@ func NewSongService(repo *repository.SongRepository, client MusicInfoClient, log
 
 // AddSong добавляет новую песню в библиотеку
 func (s *SongService) AddSong(ctx context.Context, req *entity.Song) (*entity.Song, error) {
+	if err := auth.Require(ctx, auth.PermCreate); err != nil {
+		return nil, err
+	}
 	info, err := s.infoClient.GetSongInfo(ctx, req.Group, req.Title)
 	if err != nil {
-		s.logger.WithFields(logrus.Fields{
+		entry := s.logger.WithFields(logrus.Fields{
 			"error": err,
 			"group": req.Group,
 			"title": req.Title,
-		}).Error("Failed to get song info")
-		return nil, err
+		})
+		if errors.Is(err, context.Canceled) {
+			entry.Info("Song info request canceled")
+		} else {
+			entry.Error("Failed to get song info")
+		}
+		return nil, providerAppError(err)
 	}
 
 	req.ReleaseDate = info.ReleaseDate
 	req.Text = info.Text
 	req.Link = info.Link
+	req.Provenance = info.Provenance
+	syncedAt := time.Now()
+	req.SyncedAt = &syncedAt
 
 	if err := s.repo.Create(req); err != nil {
 		s.logger.WithFields(logrus.Fields{
@@ -43,21 +75,37 @@ func (s *SongService) AddSong(ctx context.Context, req *entity.Song) (*entity.So
 			"group": req.Group,
 			"title": req.Title,
 		}).Error("Failed to create song")
-		return nil, err
+		return nil, slugConflictError(err)
 	}
 
-	s.logger.WithFields(logrus.Fields{
-		"id":    req.ID,
-		"group": req.Group,
-		"title": req.Title,
-	}).Info("Song created successfully")
+	s.logger.WithFields(auditFields(ctx, logrus.Fields{
+		"id":      req.ID,
+		"group":   req.Group,
+		"title":   req.Title,
+		"sources": req.Provenance,
+	})).Info("Song created successfully")
 
 	return req, nil
 }
 
-// GetSongs возвращает список песен с фильтрацией и пагинацией
-func (s *SongService) GetSongs(ctx context.Context, filter map[string]string, page, size int) ([]entity.Song, error) {
-	songs, err := s.repo.GetPaginated(filter, page, size)
+// GetSongs возвращает страницу песен с фильтрацией и общее число подходящих песен.
+// fields ограничивает читаемые поля; пустой список означает все поля.
+func (s *SongService) GetSongs(ctx context.Context, filter map[string]string, order SongOrder, fields []string, page, size int) ([]entity.Song, int64, error) {
+	if order.Field != "" && !repository.IsSongOrderField(order.Field) {
+		return nil, 0, apperror.Validation("unknown_field", fmt.Sprintf("Cannot sort by %q", order.Field), nil)
+	}
+	for _, field := range fields {
+		if !repository.IsSongField(field) {
+			return nil, 0, apperror.Validation("unknown_field", fmt.Sprintf("Unknown song field %q", field), nil)
+		}
+	}
+
+	total, err := s.repo.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	songs, err := s.repo.GetPaginated(filter, order, fields, page, size)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error":  err,
@@ -65,70 +113,339 @@ func (s *SongService) GetSongs(ctx context.Context, filter map[string]string, pa
 			"page":   page,
 			"size":   size,
 		}).Error("Failed to get songs")
-		return nil, err
+		return nil, 0, err
 	}
 
 	s.logger.WithFields(logrus.Fields{
 		"count": len(songs),
+		"total": total,
 		"page":  page,
 		"size":  size,
 	}).Info("Songs retrieved successfully")
 
-	return songs, nil
+	return songs, total, nil
 }
 
-// GetSongText возвращает текст песни с пагинацией по куплетам
-func (s *SongService) GetSongText(ctx context.Context, id uint, page, size int) ([]string, error) {
+// GetSong возвращает песню по ID
+func (s *SongService) GetSong(ctx context.Context, id uint) (*entity.Song, error) {
 	song, err := s.repo.GetByID(id)
 	if err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err,
 			"id":    id,
 		}).Error("Failed to get song by ID")
+		return nil, songError(id, err)
+	}
+	return song, nil
+}
+
+// GetSongsByIDs возвращает песни в порядке запрошенных ссылок и ссылки,
+// для которых песни не нашлись. Повторные ссылки учитываются один раз.
+func (s *SongService) GetSongsByIDs(ctx context.Context, refs []SongRef, fields []string) ([]entity.Song, []SongRef, error) {
+	for _, field := range fields {
+		if !repository.IsSongField(field) {
+			return nil, nil, apperror.Validation("unknown_field", fmt.Sprintf("Unknown song field %q", field), nil)
+		}
+	}
+	if len(fields) > 0 && !slices.Contains(fields, "public_id") {
+		fields = append(slices.Clip(fields), "public_id")
+	}
+
+	var ids []uint
+	var publicIDs []uuid.UUID
+	for _, ref := range refs {
+		if ref.PublicID != uuid.Nil {
+			publicIDs = append(publicIDs, ref.PublicID)
+		} else {
+			ids = append(ids, ref.ID)
+		}
+	}
+
+	found, err := s.repo.GetByIDs(ids, publicIDs, fields)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"refs":  refs,
+		}).Error("Failed to get songs by IDs")
+		return nil, nil, err
+	}
+
+	songs, missing := orderByRefs(found, refs)
+
+	s.logger.WithFields(logrus.Fields{
+		"requested": len(songs) + len(missing),
+		"found":     len(songs),
+		"missing":   len(missing),
+	}).Info("Songs retrieved by IDs")
+
+	return songs, missing, nil
+}
+
+// orderByRefs раскладывает найденные песни в порядке ссылок refs и
+// возвращает ссылки, для которых песни не нашлись. Песня выводится один раз,
+// даже если на неё ссылаются и по ID, и по UUID.
+func orderByRefs(found []entity.Song, refs []SongRef) ([]entity.Song, []SongRef) {
+	byRef := make(map[SongRef]entity.Song, 2*len(found))
+	for _, song := range found {
+		byRef[SongRef{ID: song.ID}] = song
+		byRef[SongRef{PublicID: song.PublicID}] = song
+	}
+
+	songs := make([]entity.Song, 0, len(found))
+	missing := make([]SongRef, 0)
+	seen := make(map[SongRef]bool, len(refs))
+	added := make(map[uint]bool, len(found))
+	for _, ref := range refs {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		song, ok := byRef[ref]
+		if !ok {
+			missing = append(missing, ref)
+			continue
+		}
+		if !added[song.ID] {
+			added[song.ID] = true
+			songs = append(songs, song)
+		}
+	}
+	return songs, missing
+}
+
+// ResolveSongID возвращает внутренний ID песни по ссылке из запроса:
+// числовому ID или публичному UUID
+func (s *SongService) ResolveSongID(ctx context.Context, ref string) (uint, error) {
+	return resolveSongID(s.repo, ref)
+}
+
+// GetSongBySlug возвращает песню по слагу. Для прежнего слага moved
+// сообщает, что клиенту следует перейти на актуальный.
+func (s *SongService) GetSongBySlug(ctx context.Context, songSlug string) (song *entity.Song, moved bool, err error) {
+	song, err = s.repo.GetBySlug(songSlug)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		song, err = s.repo.GetByPreviousSlug(songSlug)
+		moved = true
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, apperror.NotFound("song_not_found", fmt.Sprintf("Song %s not found", songSlug), err)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"id":    song.ID,
+		"slug":  songSlug,
+		"moved": moved,
+	}).Info("Song retrieved by slug")
+
+	return song, moved, nil
+}
+
+// FindSong находит песню по исполнителю и названию
+func (s *SongService) FindSong(ctx context.Context, group, title string) (*entity.Song, error) {
+	song, err := s.repo.GetByGroupAndTitle(group, title)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.NotFound("song_not_found", fmt.Sprintf("Song %q by %q not found", title, group), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return song, nil
+}
+
+// GetArtists возвращает страницу исполнителей и их общее число
+func (s *SongService) GetArtists(ctx context.Context, page, size int) ([]Artist, int64, error) {
+	total, err := s.repo.CountGroups()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	groups, err := s.repo.GetGroups(page, size)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	artists := make([]Artist, 0, len(groups))
+	for _, g := range groups {
+		artists = append(artists, Artist{Name: g.Group, SongCount: g.Songs})
+	}
+	return artists, total, nil
+}
+
+// CountSongsByArtists возвращает число песен каждого исполнителя
+func (s *SongService) CountSongsByArtists(ctx context.Context, names []string) (map[string]int64, error) {
+	counts, err := s.repo.CountByGroups(names)
+	if err != nil {
 		return nil, err
 	}
 
+	byName := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		byName[c.Group] = c.Songs
+	}
+	return byName, nil
+}
+
+// GetSongsByArtists возвращает песни исполнителей, сгруппированные по имени
+func (s *SongService) GetSongsByArtists(ctx context.Context, names []string) (map[string][]entity.Song, error) {
+	songs, err := s.repo.GetByGroups(names)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string][]entity.Song, len(names))
+	for _, song := range songs {
+		byName[song.Group] = append(byName[song.Group], song)
+	}
+	return byName, nil
+}
+
+// GetSongText возвращает песню, страницу её куплетов и общее число куплетов
+func (s *SongService) GetSongText(ctx context.Context, id uint, page, size int) (*entity.Song, []string, int, error) {
+	song, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to get song by ID")
+		return nil, nil, 0, songError(id, err)
+	}
+
+	total := len(song.Verses())
 	verses := song.GetVerses(page, size)
 	s.logger.WithFields(logrus.Fields{
 		"id":     id,
 		"page":   page,
 		"size":   size,
 		"verses": len(verses),
+		"total":  total,
 	}).Info("Song verses retrieved successfully")
 
-	return verses, nil
+	return song, verses, total, nil
 }
 
-// UpdateSong обновляет данные песни
+// UpdateSong обновляет данные песни.
+// Изменённые поля помечаются как отредактированные вручную.
 func (s *SongService) UpdateSong(ctx context.Context, song *entity.Song) error {
+	if err := auth.Require(ctx, auth.PermUpdate); err != nil {
+		return err
+	}
+	current, err := s.repo.GetByID(song.ID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    song.ID,
+		}).Error("Failed to get song by ID")
+		return songError(song.ID, err)
+	}
+
+	song.PublicID = current.PublicID
+	song.Slug = current.Slug
+	song.Provenance = current.Provenance
+	song.SyncedAt = current.SyncedAt
+	song.CreatedAt = current.CreatedAt
+	editedAt := time.Now()
+	for _, field := range songInfoFields {
+		if fieldValue(song, field) != fieldValue(current, field) {
+			song.SetSource(field, entity.SourceManual, editedAt)
+		}
+	}
+
 	if err := s.repo.Update(song); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err,
 			"id":    song.ID,
 		}).Error("Failed to update song")
-		return err
+		return slugConflictError(songError(song.ID, err))
 	}
 
-	s.logger.WithFields(logrus.Fields{
+	s.logger.WithFields(auditFields(ctx, logrus.Fields{
 		"id": song.ID,
-	}).Info("Song updated successfully")
+	})).Info("Song updated successfully")
 
 	return nil
 }
 
+// SetFieldLocks включает или снимает защиту полей от перезаписи провайдером
+func (s *SongService) SetFieldLocks(ctx context.Context, id uint, locks map[string]bool) (*entity.Song, error) {
+	if err := auth.Require(ctx, auth.PermUpdate); err != nil {
+		return nil, err
+	}
+	for field := range locks {
+		if !isSongInfoField(field) {
+			return nil, apperror.Validation("unknown_field", fmt.Sprintf("Unknown song field %q", field), nil)
+		}
+	}
+
+	song, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to get song by ID")
+		return nil, songError(id, err)
+	}
+
+	if song.Provenance == nil {
+		song.Provenance = make(map[string]entity.FieldProvenance)
+	}
+	for field, locked := range locks {
+		p := song.Provenance[field]
+		p.Locked = locked
+		song.Provenance[field] = p
+	}
+
+	if err := s.repo.Update(song); err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("Failed to update field locks")
+		return nil, err
+	}
+
+	s.logger.WithFields(auditFields(ctx, logrus.Fields{
+		"id":    id,
+		"locks": locks,
+	})).Info("Song field locks updated successfully")
+
+	return song, nil
+}
+
 // DeleteSong удаляет песню по ID
 func (s *SongService) DeleteSong(ctx context.Context, id uint) error {
+	if err := auth.Require(ctx, auth.PermDelete); err != nil {
+		return err
+	}
 	if err := s.repo.Delete(id); err != nil {
 		s.logger.WithFields(logrus.Fields{
 			"error": err,
 			"id":    id,
 		}).Error("Failed to delete song")
-		return err
+		return songError(id, err)
 	}
 
-	s.logger.WithFields(logrus.Fields{
+	s.logger.WithFields(auditFields(ctx, logrus.Fields{
 		"id": id,
-	}).Info("Song deleted successfully")
+	})).Info("Song deleted successfully")
 
 	return nil
 }
+
+// slugConflictError сообщает о слаге, который заняли параллельные запросы,
+// как о конфликте: запрос можно повторить
+func slugConflictError(err error) error {
+	if errors.Is(err, repository.ErrSlugConflict) {
+		return apperror.Conflict("slug_conflict", "Song slug is taken by a concurrent request, retry the request", err)
+	}
+	return err
+}
+
+// songError переводит отсутствие записи в доменную ошибку NotFound
+func songError(id uint, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.NotFound("song_not_found", fmt.Sprintf("Song %d not found", id), err)
+	}
+	return err
+}
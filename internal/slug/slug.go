@@ -0,0 +1,82 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// partLength ограничивает длину каждой части слага песни
+const partLength = 120
+
+// cyrillic задаёт транслитерацию русского алфавита
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// Make приводит строку к виду для URL: строчные буквы и цифры,
+// разделённые дефисами. Кириллица транслитерируется, диакритика
+// отбрасывается, прочие буквы сохраняются как есть.
+func Make(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if t, ok := cyrillic[r]; ok {
+			b.WriteString(t)
+			dash = dash && t == ""
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			for _, d := range norm.NFKD.String(string(r)) {
+				if !unicode.Is(unicode.Mn, d) {
+					b.WriteRune(d)
+				}
+			}
+			dash = false
+			continue
+		}
+		if b.Len() > 0 && !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimRight(truncate(b.String(), partLength), "-")
+}
+
+// Song строит слаг песни вида "исполнитель/название"
+func Song(group, title string) string {
+	return orDefault(Make(group), "unknown") + "/" + orDefault(Make(title), "untitled")
+}
+
+// WithSuffix добавляет к слагу номер, различающий песни с одинаковым слагом
+func WithSuffix(s string, n int) string {
+	if n < 2 {
+		return s
+	}
+	return s + "-" + strconv.Itoa(n)
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// truncate обрезает строку до n байт, не разрывая символы
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,91 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Bohemian Rhapsody", "bohemian-rhapsody"},
+		{"  AC/DC  ", "ac-dc"},
+		{"T.N.T.", "t-n-t"},
+		{"Кино", "kino"},
+		{"Группа крови", "gruppa-krovi"},
+		{"Щука и ёж", "shchuka-i-ezh"},
+		{"Объявление", "obyavlenie"},
+		{"Beyoncé — Déjà Vu", "beyonce-deja-vu"},
+		{"Ｆｕｌｌｗｉｄｔｈ", "fullwidth"},
+		{"Ελλάδα", "ελλαδα"},
+		{"99 Luftballons", "99-luftballons"},
+		{"---", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Make(tt.in); got != tt.want {
+			t.Errorf("Make(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMakeTruncates(t *testing.T) {
+	tests := []string{
+		strings.Repeat("a", 500),
+		strings.Repeat("я", 500),
+		strings.Repeat("ab ", 100),
+		strings.Repeat("λ", 500),
+	}
+	for _, in := range tests {
+		got := Make(in)
+		if len(got) > partLength {
+			t.Errorf("Make(%.10q…) has %d bytes, want at most %d", in, len(got), partLength)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Make(%.10q…) = %q is not valid UTF-8", in, got)
+		}
+		if strings.HasSuffix(got, "-") {
+			t.Errorf("Make(%.10q…) = %q ends with a hyphen", in, got)
+		}
+	}
+}
+
+func TestSong(t *testing.T) {
+	tests := []struct {
+		group, title, want string
+	}{
+		{"Queen", "Bohemian Rhapsody", "queen/bohemian-rhapsody"},
+		{"Кино", "Группа крови", "kino/gruppa-krovi"},
+		{"", "", "unknown/untitled"},
+		{"!!!", "???", "unknown/untitled"},
+	}
+	for _, tt := range tests {
+		if got := Song(tt.group, tt.title); got != tt.want {
+			t.Errorf("Song(%q, %q) = %q, want %q", tt.group, tt.title, got, tt.want)
+		}
+	}
+
+	long := Song(strings.Repeat("x", 1000), strings.Repeat("y", 1000))
+	if len(long) > 2*partLength+1 {
+		t.Errorf("slug of long names has %d bytes, want at most %d", len(long), 2*partLength+1)
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "queen/innuendo"},
+		{1, "queen/innuendo"},
+		{2, "queen/innuendo-2"},
+		{12, "queen/innuendo-12"},
+	}
+	for _, tt := range tests {
+		if got := WithSuffix("queen/innuendo", tt.n); got != tt.want {
+			t.Errorf("WithSuffix(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+)
+
+// AddSongRequest — тело запроса на добавление песни
+// @Description Add song request
+type AddSongRequest struct {
+	Group string `json:"group" binding:"required,max=255"`
+	Title string `json:"title" binding:"required,max=255"`
+}
+
+// Song возвращает новую песню из запроса
+func (r AddSongRequest) Song() *entity.Song {
+	return &entity.Song{
+		Group: r.Group,
+		Title: r.Title,
+	}
+}
+
+// UpdateSongRequest — тело запроса на обновление песни
+// @Description Update song request
+type UpdateSongRequest struct {
+	Group       string    `json:"group" binding:"required,max=255"`
+	Title       string    `json:"title" binding:"required,max=255"`
+	ReleaseDate time.Time `json:"release_date" binding:"required"`
+	Text        string    `json:"text" binding:"required,max=20000"`
+	Link        string    `json:"link" binding:"required,url,max=2048"`
+}
+
+// Song возвращает песню id с данными из запроса
+func (r UpdateSongRequest) Song(id uint) *entity.Song {
+	return &entity.Song{
+		ID:          id,
+		Group:       r.Group,
+		Title:       r.Title,
+		ReleaseDate: r.ReleaseDate,
+		Text:        r.Text,
+		Link:        r.Link,
+	}
+}
+
+// PageQuery — параметры пагинации
+type PageQuery struct {
+	Page int `form:"page,default=1" binding:"min=1"`
+	Size int `form:"size,default=10" binding:"min=1,max=100"`
+}
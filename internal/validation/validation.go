@@ -0,0 +1,87 @@
+// Package validation проверяет входные данные по тегам binding одинаково
+// для REST, GraphQL и gRPC и описывает общие для них запросы.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName возвращает имя поля так, как его видит клиент
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// Struct проверяет структуру по тегам binding так же, как тела
+// REST-запросов, и возвращает ошибку валидации со списком полей
+func Struct(obj any) error {
+	return Error(binding.Validator.ValidateStruct(obj))
+}
+
+// Error переводит ошибку привязки или проверки в ошибку валидации
+// со списком полей
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]apperror.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, apperror.FieldError{Field: fe.Field(), Reason: reason(fe)})
+		}
+		return apperror.InvalidFields(fields, err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return apperror.InvalidFields([]apperror.FieldError{{
+			Field:  typeErr.Field,
+			Reason: "must be of type " + typeErr.Type.String(),
+		}}, err)
+	}
+
+	return apperror.Validation("invalid_body", "Request body is malformed: "+err.Error(), err)
+}
+
+func reason(fe validator.FieldError) string {
+	unit := ""
+	if fe.Kind() == reflect.String {
+		unit = " characters"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		return fmt.Sprintf("must be at most %s%s", fe.Param(), unit)
+	case "min":
+		return fmt.Sprintf("must be at least %s%s", fe.Param(), unit)
+	case "url":
+		return "must be a valid URL"
+	}
+	return fmt.Sprintf("failed the %q rule", fe.Tag())
+}
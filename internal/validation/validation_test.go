@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+)
+
+func TestStruct(t *testing.T) {
+	valid := UpdateSongRequest{
+		Group:       "Muse",
+		Title:       "Uprising",
+		ReleaseDate: time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC),
+		Text:        "Paranoia is in bloom",
+		Link:        "https://www.youtube.com/watch?v=w8KQmps-Sog",
+	}
+	invalidLink := valid
+	invalidLink.Link = "not a link"
+
+	tests := []struct {
+		name   string
+		obj    any
+		fields []apperror.FieldError
+	}{
+		{"корректная песня", valid, nil},
+		{"пустой запрос на добавление", AddSongRequest{}, []apperror.FieldError{
+			{Field: "group", Reason: "is required"},
+			{Field: "title", Reason: "is required"},
+		}},
+		{"длинное название", AddSongRequest{Group: "Muse", Title: strings.Repeat("a", 256)}, []apperror.FieldError{
+			{Field: "title", Reason: "must be at most 255 characters"},
+		}},
+		{"некорректная ссылка", invalidLink, []apperror.FieldError{
+			{Field: "link", Reason: "must be a valid URL"},
+		}},
+		// имена полей берутся из тега form, как их видит клиент
+		{"страница вне границ", PageQuery{Page: 0, Size: 101}, []apperror.FieldError{
+			{Field: "page", Reason: "must be at least 1"},
+			{Field: "size", Reason: "must be at most 100"},
+		}},
+	}
+	for _, tt := range tests {
+		err := Struct(tt.obj)
+		if tt.fields == nil {
+			if err != nil {
+				t.Errorf("%s: Struct() error = %v", tt.name, err)
+			}
+			continue
+		}
+		appErr, ok := apperror.As(err)
+		if !ok || appErr.Kind != apperror.KindValidation {
+			t.Errorf("%s: Struct() error = %v, want validation error", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(appErr.Fields, tt.fields) {
+			t.Errorf("%s: fields = %+v, want %+v", tt.name, appErr.Fields, tt.fields)
+		}
+	}
+}
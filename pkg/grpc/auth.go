@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	pb "github.com/DusmatzodaQurbonli/song-library/pkg/grpc/songlibraryv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// methodPermissions задаёт право, необходимое для вызова метода SongLibrary.
+// Health и reflection доступны без ключа.
+var methodPermissions = map[string]auth.Permission{
+	pb.SongLibrary_GetSong_FullMethodName:    auth.PermRead,
+	pb.SongLibrary_ListSongs_FullMethodName:  auth.PermRead,
+	pb.SongLibrary_GetVerses_FullMethodName:  auth.PermRead,
+	pb.SongLibrary_AddSong_FullMethodName:    auth.PermCreate,
+	pb.SongLibrary_UpdateSong_FullMethodName: auth.PermUpdate,
+	pb.SongLibrary_DeleteSong_FullMethodName: auth.PermDelete,
+}
+
+func (s *Server) authUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) authStreamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate определяет субъект вызова по API-ключу или JWT из метаданных
+// authorization (Bearer) либо по ключу из x-api-key, списывает вызов из его
+// бюджета запросов и проверяет право на метод. Неудачная аутентификация
+// списывается с бюджета адреса клиента.
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	perm, ok := methodPermissions[method]
+	if !ok {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	credential := auth.KeyFromHeaders(first(md.Get("authorization")), first(md.Get("x-api-key")))
+	principal, err := s.authenticator.Authenticate(ctx, credential)
+	if err != nil {
+		// неудачная попытка расходует бюджет адреса, иначе перебор ключей
+		// не ограничен ничем
+		if _, limitErr := s.rateLimits.Allow(ctx, service.BudgetFailedAuth, service.RateLimitClient(ctx, peerAddr(ctx))); limitErr != nil {
+			err = limitErr
+		}
+		return nil, statusError(err)
+	}
+
+	ctx = auth.WithPrincipal(ctx, principal)
+	if _, err := s.rateLimits.Allow(ctx, service.BudgetFor(perm), service.RateLimitClient(ctx, peerAddr(ctx))); err != nil {
+		return nil, statusError(err)
+	}
+	if err := auth.Require(ctx, perm); err != nil {
+		return nil, statusError(err)
+	}
+	return ctx, nil
+}
+
+// peerAddr возвращает адрес клиента без порта
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// authenticatedStream передаёт обработчику контекст с субъектом вызова
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
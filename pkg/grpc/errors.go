@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain — домен ErrorInfo в ошибках сервиса
+const errorDomain = "song-library"
+
+var kindCodes = map[apperror.Kind]codes.Code{
+	apperror.KindNotFound:     codes.NotFound,
+	apperror.KindValidation:   codes.InvalidArgument,
+	apperror.KindConflict:     codes.FailedPrecondition,
+	apperror.KindUnavailable:  codes.Unavailable,
+	apperror.KindUnauthorized: codes.Unauthenticated,
+	apperror.KindForbidden:    codes.PermissionDenied,
+	apperror.KindRateLimited:  codes.ResourceExhausted,
+	apperror.KindInternal:     codes.Internal,
+}
+
+// statusError переводит доменную ошибку в статус gRPC с деталями
+// ErrorInfo, BadRequest и RetryInfo. Детали неизвестных ошибок
+// клиенту не раскрываются.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, context.Canceled.Error())
+	}
+
+	appErr, ok := apperror.As(err)
+	if !ok {
+		return status.Error(codes.Internal, http.StatusText(http.StatusInternalServerError))
+	}
+
+	code, ok := kindCodes[appErr.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+	if appErr.HTTPStatus() == http.StatusGatewayTimeout {
+		code = codes.DeadlineExceeded
+	}
+
+	st := status.New(code, appErr.Detail)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: appErr.Code, Domain: errorDomain}}
+	if len(appErr.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, f := range appErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: f.Reason,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if appErr.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(appErr.RetryAfter)})
+	}
+
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// invalidField возвращает ошибку валидации одного поля запроса
+func invalidField(field, reason string) error {
+	return statusError(apperror.InvalidFields([]apperror.FieldError{{Field: field, Reason: reason}}, nil))
+}
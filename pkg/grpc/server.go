@@ -0,0 +1,151 @@
+// Package grpc обслуживает gRPC-сервис SongLibrary для внутренних сервисов.
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime/debug"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	pb "github.com/DusmatzodaQurbonli/song-library/pkg/grpc/songlibraryv1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// shutdownTimeout ограничивает ожидание завершения активных вызовов при остановке
+const shutdownTimeout = 5 * time.Second
+
+type Server struct {
+	server        *grpc.Server
+	health        *health.Server
+	authenticator *service.AuthService
+	rateLimits    *service.RateLimitService
+	log           *logrus.Logger
+	config        *config.Config
+}
+
+func NewServer(
+	songs *service.SongService,
+	authenticator *service.AuthService,
+	rateLimits *service.RateLimitService,
+	log *logrus.Logger,
+	config *config.Config,
+) *Server {
+	s := &Server{
+		health:        health.NewServer(),
+		authenticator: authenticator,
+		rateLimits:    rateLimits,
+		log:           log,
+		config:        config,
+	}
+
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor, s.authUnaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor, s.authStreamInterceptor),
+	)
+	pb.RegisterSongLibraryServer(s.server, &songLibraryServer{songs: songs})
+	healthpb.RegisterHealthServer(s.server, s.health)
+	if config.GRPC.Reflection {
+		reflection.Register(s.server)
+	}
+
+	return s
+}
+
+// Start начинает принимать соединения на настроенном адресе
+func (s *Server) Start() error {
+	addr := net.JoinHostPort(s.config.GRPC.Host, s.config.GRPC.Port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus(pb.SongLibrary_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil {
+			s.log.Errorf("gRPC server error: %v", err)
+		}
+	}()
+
+	s.log.Infof("gRPC server is running on %s", addr)
+	return nil
+}
+
+// Stop переводит сервис в NOT_SERVING и дожидается завершения активных вызовов
+func (s *Server) Stop() {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(shutdownTimeout):
+		s.server.Stop()
+	}
+	s.log.Info("gRPC server stopped")
+}
+
+func (s *Server) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(info.FullMethod, r)
+		}
+		s.logCall(ctx, info.FullMethod, start, err)
+	}()
+	return handler(ctx, req)
+}
+
+func (s *Server) streamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(info.FullMethod, r)
+		}
+		s.logCall(stream.Context(), info.FullMethod, start, err)
+	}()
+	return handler(srv, stream)
+}
+
+func (s *Server) recovered(method string, r any) error {
+	s.log.WithFields(logrus.Fields{
+		"method": method,
+		"panic":  r,
+		"stack":  string(debug.Stack()),
+	}).Error("gRPC handler panicked")
+	return status.Error(codes.Internal, "Internal Server Error")
+}
+
+func (s *Server) logCall(ctx context.Context, method string, start time.Time, err error) {
+	client := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		client = p.Addr.String()
+	}
+
+	s.log.Infof("[%s] %s | %s | %v", status.Code(err), method, client, time.Since(start))
+}
@@ -0,0 +1,5 @@
+// Package songlibraryv1 содержит типы и заглушки gRPC-сервиса SongLibrary,
+// сгенерированные из docs/proto/songlibrary/v1/songlibrary.proto.
+package songlibraryv1
+
+//go:generate protoc -I ../../../docs/proto --go_out=../../.. --go_opt=module=github.com/DusmatzodaQurbonli/song-library --go-grpc_out=../../.. --go-grpc_opt=module=github.com/DusmatzodaQurbonli/song-library songlibrary/v1/songlibrary.proto
@@ -0,0 +1,1063 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: songlibrary/v1/songlibrary.proto
+
+package songlibraryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SongOrderField int32
+
+const (
+	SongOrderField_SONG_ORDER_FIELD_UNSPECIFIED  SongOrderField = 0
+	SongOrderField_SONG_ORDER_FIELD_ID           SongOrderField = 1
+	SongOrderField_SONG_ORDER_FIELD_GROUP        SongOrderField = 2
+	SongOrderField_SONG_ORDER_FIELD_TITLE        SongOrderField = 3
+	SongOrderField_SONG_ORDER_FIELD_RELEASE_DATE SongOrderField = 4
+	SongOrderField_SONG_ORDER_FIELD_CREATED_AT   SongOrderField = 5
+	SongOrderField_SONG_ORDER_FIELD_UPDATED_AT   SongOrderField = 6
+)
+
+// Enum value maps for SongOrderField.
+var (
+	SongOrderField_name = map[int32]string{
+		0: "SONG_ORDER_FIELD_UNSPECIFIED",
+		1: "SONG_ORDER_FIELD_ID",
+		2: "SONG_ORDER_FIELD_GROUP",
+		3: "SONG_ORDER_FIELD_TITLE",
+		4: "SONG_ORDER_FIELD_RELEASE_DATE",
+		5: "SONG_ORDER_FIELD_CREATED_AT",
+		6: "SONG_ORDER_FIELD_UPDATED_AT",
+	}
+	SongOrderField_value = map[string]int32{
+		"SONG_ORDER_FIELD_UNSPECIFIED":  0,
+		"SONG_ORDER_FIELD_ID":           1,
+		"SONG_ORDER_FIELD_GROUP":        2,
+		"SONG_ORDER_FIELD_TITLE":        3,
+		"SONG_ORDER_FIELD_RELEASE_DATE": 4,
+		"SONG_ORDER_FIELD_CREATED_AT":   5,
+		"SONG_ORDER_FIELD_UPDATED_AT":   6,
+	}
+)
+
+func (x SongOrderField) Enum() *SongOrderField {
+	p := new(SongOrderField)
+	*p = x
+	return p
+}
+
+func (x SongOrderField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SongOrderField) Descriptor() protoreflect.EnumDescriptor {
+	return file_songlibrary_v1_songlibrary_proto_enumTypes[0].Descriptor()
+}
+
+func (SongOrderField) Type() protoreflect.EnumType {
+	return &file_songlibrary_v1_songlibrary_proto_enumTypes[0]
+}
+
+func (x SongOrderField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SongOrderField.Descriptor instead.
+func (SongOrderField) EnumDescriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{0}
+}
+
+type Song struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Group       string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Title       string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	ReleaseDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	Text        string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Link        string                 `protobuf:"bytes,6,opt,name=link,proto3" json:"link,omitempty"`
+	SyncedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=synced_at,json=syncedAt,proto3" json:"synced_at,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Stable public UUID that survives database merges.
+	PublicId string `protobuf:"bytes,10,opt,name=public_id,json=publicId,proto3" json:"public_id,omitempty"`
+	// Human-readable slug such as "queen/bohemian-rhapsody".
+	Slug          string `protobuf:"bytes,11,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Song) Reset() {
+	*x = Song{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Song) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Song) ProtoMessage() {}
+
+func (x *Song) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Song.ProtoReflect.Descriptor instead.
+func (*Song) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Song) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Song) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Song) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Song) GetReleaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return nil
+}
+
+func (x *Song) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Song) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Song) GetSyncedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SyncedAt
+	}
+	return nil
+}
+
+func (x *Song) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Song) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Song) GetPublicId() string {
+	if x != nil {
+		return x.PublicId
+	}
+	return ""
+}
+
+func (x *Song) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type GetSongRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Song:
+	//
+	//	*GetSongRequest_Id
+	//	*GetSongRequest_PublicId
+	Song          isGetSongRequest_Song `protobuf_oneof:"song"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSongRequest) Reset() {
+	*x = GetSongRequest{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSongRequest) ProtoMessage() {}
+
+func (x *GetSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSongRequest.ProtoReflect.Descriptor instead.
+func (*GetSongRequest) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSongRequest) GetSong() isGetSongRequest_Song {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *GetSongRequest) GetId() uint64 {
+	if x != nil {
+		if x, ok := x.Song.(*GetSongRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *GetSongRequest) GetPublicId() string {
+	if x != nil {
+		if x, ok := x.Song.(*GetSongRequest_PublicId); ok {
+			return x.PublicId
+		}
+	}
+	return ""
+}
+
+type isGetSongRequest_Song interface {
+	isGetSongRequest_Song()
+}
+
+type GetSongRequest_Id struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type GetSongRequest_PublicId struct {
+	PublicId string `protobuf:"bytes,2,opt,name=public_id,json=publicId,proto3,oneof"`
+}
+
+func (*GetSongRequest_Id) isGetSongRequest_Song() {}
+
+func (*GetSongRequest_PublicId) isGetSongRequest_Song() {}
+
+type ListSongsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Exact-match filters; empty values are ignored.
+	Group      string         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Title      string         `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	OrderBy    SongOrderField `protobuf:"varint,3,opt,name=order_by,json=orderBy,proto3,enum=songlibrary.v1.SongOrderField" json:"order_by,omitempty"`
+	Descending bool           `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	// Maximum number of songs to stream; 0 streams all matches.
+	Limit         uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSongsRequest) Reset() {
+	*x = ListSongsRequest{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSongsRequest) ProtoMessage() {}
+
+func (x *ListSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSongsRequest.ProtoReflect.Descriptor instead.
+func (*ListSongsRequest) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSongsRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ListSongsRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ListSongsRequest) GetOrderBy() SongOrderField {
+	if x != nil {
+		return x.OrderBy
+	}
+	return SongOrderField_SONG_ORDER_FIELD_UNSPECIFIED
+}
+
+func (x *ListSongsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListSongsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AddSongRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSongRequest) Reset() {
+	*x = AddSongRequest{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSongRequest) ProtoMessage() {}
+
+func (x *AddSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSongRequest.ProtoReflect.Descriptor instead.
+func (*AddSongRequest) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddSongRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *AddSongRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type UpdateSongRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Song:
+	//
+	//	*UpdateSongRequest_Id
+	//	*UpdateSongRequest_PublicId
+	Song          isUpdateSongRequest_Song `protobuf_oneof:"song"`
+	Group         string                   `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Title         string                   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	ReleaseDate   *timestamppb.Timestamp   `protobuf:"bytes,4,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	Text          string                   `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Link          string                   `protobuf:"bytes,6,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSongRequest) Reset() {
+	*x = UpdateSongRequest{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSongRequest) ProtoMessage() {}
+
+func (x *UpdateSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSongRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSongRequest) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateSongRequest) GetSong() isUpdateSongRequest_Song {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *UpdateSongRequest) GetId() uint64 {
+	if x != nil {
+		if x, ok := x.Song.(*UpdateSongRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *UpdateSongRequest) GetPublicId() string {
+	if x != nil {
+		if x, ok := x.Song.(*UpdateSongRequest_PublicId); ok {
+			return x.PublicId
+		}
+	}
+	return ""
+}
+
+func (x *UpdateSongRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *UpdateSongRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateSongRequest) GetReleaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return nil
+}
+
+func (x *UpdateSongRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *UpdateSongRequest) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+type isUpdateSongRequest_Song interface {
+	isUpdateSongRequest_Song()
+}
+
+type UpdateSongRequest_Id struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type UpdateSongRequest_PublicId struct {
+	PublicId string `protobuf:"bytes,7,opt,name=public_id,json=publicId,proto3,oneof"`
+}
+
+func (*UpdateSongRequest_Id) isUpdateSongRequest_Song() {}
+
+func (*UpdateSongRequest_PublicId) isUpdateSongRequest_Song() {}
+
+type DeleteSongRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Song:
+	//
+	//	*DeleteSongRequest_Id
+	//	*DeleteSongRequest_PublicId
+	Song          isDeleteSongRequest_Song `protobuf_oneof:"song"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSongRequest) Reset() {
+	*x = DeleteSongRequest{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSongRequest) ProtoMessage() {}
+
+func (x *DeleteSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSongRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSongRequest) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteSongRequest) GetSong() isDeleteSongRequest_Song {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *DeleteSongRequest) GetId() uint64 {
+	if x != nil {
+		if x, ok := x.Song.(*DeleteSongRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *DeleteSongRequest) GetPublicId() string {
+	if x != nil {
+		if x, ok := x.Song.(*DeleteSongRequest_PublicId); ok {
+			return x.PublicId
+		}
+	}
+	return ""
+}
+
+type isDeleteSongRequest_Song interface {
+	isDeleteSongRequest_Song()
+}
+
+type DeleteSongRequest_Id struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type DeleteSongRequest_PublicId struct {
+	PublicId string `protobuf:"bytes,2,opt,name=public_id,json=publicId,proto3,oneof"`
+}
+
+func (*DeleteSongRequest_Id) isDeleteSongRequest_Song() {}
+
+func (*DeleteSongRequest_PublicId) isDeleteSongRequest_Song() {}
+
+type DeleteSongResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSongResponse) Reset() {
+	*x = DeleteSongResponse{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSongResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSongResponse) ProtoMessage() {}
+
+func (x *DeleteSongResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSongResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSongResponse) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{6}
+}
+
+type GetVersesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Song:
+	//
+	//	*GetVersesRequest_Id
+	//	*GetVersesRequest_PublicId
+	Song isGetVersesRequest_Song `protobuf_oneof:"song"`
+	// Page number starting at 1; 0 means the first page.
+	Page uint32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Verses per page, at most 100; 0 means 10.
+	Size          uint32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersesRequest) Reset() {
+	*x = GetVersesRequest{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersesRequest) ProtoMessage() {}
+
+func (x *GetVersesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersesRequest.ProtoReflect.Descriptor instead.
+func (*GetVersesRequest) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetVersesRequest) GetSong() isGetVersesRequest_Song {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *GetVersesRequest) GetId() uint64 {
+	if x != nil {
+		if x, ok := x.Song.(*GetVersesRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *GetVersesRequest) GetPublicId() string {
+	if x != nil {
+		if x, ok := x.Song.(*GetVersesRequest_PublicId); ok {
+			return x.PublicId
+		}
+	}
+	return ""
+}
+
+func (x *GetVersesRequest) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetVersesRequest) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type isGetVersesRequest_Song interface {
+	isGetVersesRequest_Song()
+}
+
+type GetVersesRequest_Id struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type GetVersesRequest_PublicId struct {
+	PublicId string `protobuf:"bytes,4,opt,name=public_id,json=publicId,proto3,oneof"`
+}
+
+func (*GetVersesRequest_Id) isGetVersesRequest_Song() {}
+
+func (*GetVersesRequest_PublicId) isGetVersesRequest_Song() {}
+
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          uint32                 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          uint32                 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Total         uint64                 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	TotalPages    uint32                 `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Pagination) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Pagination) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Pagination) GetTotalPages() uint32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type GetVersesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verses        []string               `protobuf:"bytes,1,rep,name=verses,proto3" json:"verses,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersesResponse) Reset() {
+	*x = GetVersesResponse{}
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersesResponse) ProtoMessage() {}
+
+func (x *GetVersesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songlibrary_v1_songlibrary_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersesResponse.ProtoReflect.Descriptor instead.
+func (*GetVersesResponse) Descriptor() ([]byte, []int) {
+	return file_songlibrary_v1_songlibrary_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetVersesResponse) GetVerses() []string {
+	if x != nil {
+		return x.Verses
+	}
+	return nil
+}
+
+func (x *GetVersesResponse) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_songlibrary_v1_songlibrary_proto protoreflect.FileDescriptor
+
+const file_songlibrary_v1_songlibrary_proto_rawDesc = "" +
+	"\n" +
+	" songlibrary/v1/songlibrary.proto\x12\x0esonglibrary.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x03\n" +
+	"\x04Song\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12=\n" +
+	"\frelease_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vreleaseDate\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x12\n" +
+	"\x04link\x18\x06 \x01(\tR\x04link\x127\n" +
+	"\tsynced_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bsyncedAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\tpublic_id\x18\n" +
+	" \x01(\tR\bpublicId\x12\x12\n" +
+	"\x04slug\x18\v \x01(\tR\x04slug\"I\n" +
+	"\x0eGetSongRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x04H\x00R\x02id\x12\x1d\n" +
+	"\tpublic_id\x18\x02 \x01(\tH\x00R\bpublicIdB\x06\n" +
+	"\x04song\"\xaf\x01\n" +
+	"\x10ListSongsRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x129\n" +
+	"\border_by\x18\x03 \x01(\x0e2\x1e.songlibrary.v1.SongOrderFieldR\aorderBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x04 \x01(\bR\n" +
+	"descending\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\rR\x05limit\"<\n" +
+	"\x0eAddSongRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\"\xdf\x01\n" +
+	"\x11UpdateSongRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x04H\x00R\x02id\x12\x1d\n" +
+	"\tpublic_id\x18\a \x01(\tH\x00R\bpublicId\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12=\n" +
+	"\frelease_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vreleaseDate\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x12\n" +
+	"\x04link\x18\x06 \x01(\tR\x04linkB\x06\n" +
+	"\x04song\"L\n" +
+	"\x11DeleteSongRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x04H\x00R\x02id\x12\x1d\n" +
+	"\tpublic_id\x18\x02 \x01(\tH\x00R\bpublicIdB\x06\n" +
+	"\x04song\"\x14\n" +
+	"\x12DeleteSongResponse\"s\n" +
+	"\x10GetVersesRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x04H\x00R\x02id\x12\x1d\n" +
+	"\tpublic_id\x18\x04 \x01(\tH\x00R\bpublicId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\rR\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\rR\x04sizeB\x06\n" +
+	"\x04song\"k\n" +
+	"\n" +
+	"Pagination\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\rR\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\rR\x04size\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x04R\x05total\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\rR\n" +
+	"totalPages\"g\n" +
+	"\x11GetVersesResponse\x12\x16\n" +
+	"\x06verses\x18\x01 \x03(\tR\x06verses\x12:\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2\x1a.songlibrary.v1.PaginationR\n" +
+	"pagination*\xe8\x01\n" +
+	"\x0eSongOrderField\x12 \n" +
+	"\x1cSONG_ORDER_FIELD_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SONG_ORDER_FIELD_ID\x10\x01\x12\x1a\n" +
+	"\x16SONG_ORDER_FIELD_GROUP\x10\x02\x12\x1a\n" +
+	"\x16SONG_ORDER_FIELD_TITLE\x10\x03\x12!\n" +
+	"\x1dSONG_ORDER_FIELD_RELEASE_DATE\x10\x04\x12\x1f\n" +
+	"\x1bSONG_ORDER_FIELD_CREATED_AT\x10\x05\x12\x1f\n" +
+	"\x1bSONG_ORDER_FIELD_UPDATED_AT\x10\x062\xc4\x03\n" +
+	"\vSongLibrary\x12?\n" +
+	"\aGetSong\x12\x1e.songlibrary.v1.GetSongRequest\x1a\x14.songlibrary.v1.Song\x12E\n" +
+	"\tListSongs\x12 .songlibrary.v1.ListSongsRequest\x1a\x14.songlibrary.v1.Song0\x01\x12?\n" +
+	"\aAddSong\x12\x1e.songlibrary.v1.AddSongRequest\x1a\x14.songlibrary.v1.Song\x12E\n" +
+	"\n" +
+	"UpdateSong\x12!.songlibrary.v1.UpdateSongRequest\x1a\x14.songlibrary.v1.Song\x12S\n" +
+	"\n" +
+	"DeleteSong\x12!.songlibrary.v1.DeleteSongRequest\x1a\".songlibrary.v1.DeleteSongResponse\x12P\n" +
+	"\tGetVerses\x12 .songlibrary.v1.GetVersesRequest\x1a!.songlibrary.v1.GetVersesResponseBQZOgithub.com/DusmatzodaQurbonli/song-library/pkg/grpc/songlibraryv1;songlibraryv1b\x06proto3"
+
+var (
+	file_songlibrary_v1_songlibrary_proto_rawDescOnce sync.Once
+	file_songlibrary_v1_songlibrary_proto_rawDescData []byte
+)
+
+func file_songlibrary_v1_songlibrary_proto_rawDescGZIP() []byte {
+	file_songlibrary_v1_songlibrary_proto_rawDescOnce.Do(func() {
+		file_songlibrary_v1_songlibrary_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_songlibrary_v1_songlibrary_proto_rawDesc), len(file_songlibrary_v1_songlibrary_proto_rawDesc)))
+	})
+	return file_songlibrary_v1_songlibrary_proto_rawDescData
+}
+
+var file_songlibrary_v1_songlibrary_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_songlibrary_v1_songlibrary_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_songlibrary_v1_songlibrary_proto_goTypes = []any{
+	(SongOrderField)(0),           // 0: songlibrary.v1.SongOrderField
+	(*Song)(nil),                  // 1: songlibrary.v1.Song
+	(*GetSongRequest)(nil),        // 2: songlibrary.v1.GetSongRequest
+	(*ListSongsRequest)(nil),      // 3: songlibrary.v1.ListSongsRequest
+	(*AddSongRequest)(nil),        // 4: songlibrary.v1.AddSongRequest
+	(*UpdateSongRequest)(nil),     // 5: songlibrary.v1.UpdateSongRequest
+	(*DeleteSongRequest)(nil),     // 6: songlibrary.v1.DeleteSongRequest
+	(*DeleteSongResponse)(nil),    // 7: songlibrary.v1.DeleteSongResponse
+	(*GetVersesRequest)(nil),      // 8: songlibrary.v1.GetVersesRequest
+	(*Pagination)(nil),            // 9: songlibrary.v1.Pagination
+	(*GetVersesResponse)(nil),     // 10: songlibrary.v1.GetVersesResponse
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+}
+var file_songlibrary_v1_songlibrary_proto_depIdxs = []int32{
+	11, // 0: songlibrary.v1.Song.release_date:type_name -> google.protobuf.Timestamp
+	11, // 1: songlibrary.v1.Song.synced_at:type_name -> google.protobuf.Timestamp
+	11, // 2: songlibrary.v1.Song.created_at:type_name -> google.protobuf.Timestamp
+	11, // 3: songlibrary.v1.Song.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: songlibrary.v1.ListSongsRequest.order_by:type_name -> songlibrary.v1.SongOrderField
+	11, // 5: songlibrary.v1.UpdateSongRequest.release_date:type_name -> google.protobuf.Timestamp
+	9,  // 6: songlibrary.v1.GetVersesResponse.pagination:type_name -> songlibrary.v1.Pagination
+	2,  // 7: songlibrary.v1.SongLibrary.GetSong:input_type -> songlibrary.v1.GetSongRequest
+	3,  // 8: songlibrary.v1.SongLibrary.ListSongs:input_type -> songlibrary.v1.ListSongsRequest
+	4,  // 9: songlibrary.v1.SongLibrary.AddSong:input_type -> songlibrary.v1.AddSongRequest
+	5,  // 10: songlibrary.v1.SongLibrary.UpdateSong:input_type -> songlibrary.v1.UpdateSongRequest
+	6,  // 11: songlibrary.v1.SongLibrary.DeleteSong:input_type -> songlibrary.v1.DeleteSongRequest
+	8,  // 12: songlibrary.v1.SongLibrary.GetVerses:input_type -> songlibrary.v1.GetVersesRequest
+	1,  // 13: songlibrary.v1.SongLibrary.GetSong:output_type -> songlibrary.v1.Song
+	1,  // 14: songlibrary.v1.SongLibrary.ListSongs:output_type -> songlibrary.v1.Song
+	1,  // 15: songlibrary.v1.SongLibrary.AddSong:output_type -> songlibrary.v1.Song
+	1,  // 16: songlibrary.v1.SongLibrary.UpdateSong:output_type -> songlibrary.v1.Song
+	7,  // 17: songlibrary.v1.SongLibrary.DeleteSong:output_type -> songlibrary.v1.DeleteSongResponse
+	10, // 18: songlibrary.v1.SongLibrary.GetVerses:output_type -> songlibrary.v1.GetVersesResponse
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_songlibrary_v1_songlibrary_proto_init() }
+func file_songlibrary_v1_songlibrary_proto_init() {
+	if File_songlibrary_v1_songlibrary_proto != nil {
+		return
+	}
+	file_songlibrary_v1_songlibrary_proto_msgTypes[1].OneofWrappers = []any{
+		(*GetSongRequest_Id)(nil),
+		(*GetSongRequest_PublicId)(nil),
+	}
+	file_songlibrary_v1_songlibrary_proto_msgTypes[4].OneofWrappers = []any{
+		(*UpdateSongRequest_Id)(nil),
+		(*UpdateSongRequest_PublicId)(nil),
+	}
+	file_songlibrary_v1_songlibrary_proto_msgTypes[5].OneofWrappers = []any{
+		(*DeleteSongRequest_Id)(nil),
+		(*DeleteSongRequest_PublicId)(nil),
+	}
+	file_songlibrary_v1_songlibrary_proto_msgTypes[7].OneofWrappers = []any{
+		(*GetVersesRequest_Id)(nil),
+		(*GetVersesRequest_PublicId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_songlibrary_v1_songlibrary_proto_rawDesc), len(file_songlibrary_v1_songlibrary_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_songlibrary_v1_songlibrary_proto_goTypes,
+		DependencyIndexes: file_songlibrary_v1_songlibrary_proto_depIdxs,
+		EnumInfos:         file_songlibrary_v1_songlibrary_proto_enumTypes,
+		MessageInfos:      file_songlibrary_v1_songlibrary_proto_msgTypes,
+	}.Build()
+	File_songlibrary_v1_songlibrary_proto = out.File
+	file_songlibrary_v1_songlibrary_proto_goTypes = nil
+	file_songlibrary_v1_songlibrary_proto_depIdxs = nil
+}
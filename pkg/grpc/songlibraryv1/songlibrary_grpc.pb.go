@@ -0,0 +1,341 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: songlibrary/v1/songlibrary.proto
+
+package songlibraryv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SongLibrary_GetSong_FullMethodName    = "/songlibrary.v1.SongLibrary/GetSong"
+	SongLibrary_ListSongs_FullMethodName  = "/songlibrary.v1.SongLibrary/ListSongs"
+	SongLibrary_AddSong_FullMethodName    = "/songlibrary.v1.SongLibrary/AddSong"
+	SongLibrary_UpdateSong_FullMethodName = "/songlibrary.v1.SongLibrary/UpdateSong"
+	SongLibrary_DeleteSong_FullMethodName = "/songlibrary.v1.SongLibrary/DeleteSong"
+	SongLibrary_GetVerses_FullMethodName  = "/songlibrary.v1.SongLibrary/GetVerses"
+)
+
+// SongLibraryClient is the client API for SongLibrary service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SongLibrary gives backend services typed access to the song library.
+// Errors use standard status codes; validation failures carry
+// google.rpc.BadRequest details and every domain error carries
+// google.rpc.ErrorInfo with the same code as the REST problem responses.
+type SongLibraryClient interface {
+	// GetSong returns a song by ID or public ID.
+	GetSong(ctx context.Context, in *GetSongRequest, opts ...grpc.CallOption) (*Song, error)
+	// ListSongs streams every song matching the filter. The total number
+	// of matching songs is sent in the "x-total-count" header.
+	ListSongs(ctx context.Context, in *ListSongsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Song], error)
+	// AddSong adds a song; release date, text and link are fetched
+	// from the music info providers.
+	AddSong(ctx context.Context, in *AddSongRequest, opts ...grpc.CallOption) (*Song, error)
+	// UpdateSong replaces the song data.
+	UpdateSong(ctx context.Context, in *UpdateSongRequest, opts ...grpc.CallOption) (*Song, error)
+	// DeleteSong deletes a song by ID.
+	DeleteSong(ctx context.Context, in *DeleteSongRequest, opts ...grpc.CallOption) (*DeleteSongResponse, error)
+	// GetVerses returns a page of song verses.
+	GetVerses(ctx context.Context, in *GetVersesRequest, opts ...grpc.CallOption) (*GetVersesResponse, error)
+}
+
+type songLibraryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSongLibraryClient(cc grpc.ClientConnInterface) SongLibraryClient {
+	return &songLibraryClient{cc}
+}
+
+func (c *songLibraryClient) GetSong(ctx context.Context, in *GetSongRequest, opts ...grpc.CallOption) (*Song, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Song)
+	err := c.cc.Invoke(ctx, SongLibrary_GetSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) ListSongs(ctx context.Context, in *ListSongsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Song], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SongLibrary_ServiceDesc.Streams[0], SongLibrary_ListSongs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListSongsRequest, Song]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SongLibrary_ListSongsClient = grpc.ServerStreamingClient[Song]
+
+func (c *songLibraryClient) AddSong(ctx context.Context, in *AddSongRequest, opts ...grpc.CallOption) (*Song, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Song)
+	err := c.cc.Invoke(ctx, SongLibrary_AddSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) UpdateSong(ctx context.Context, in *UpdateSongRequest, opts ...grpc.CallOption) (*Song, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Song)
+	err := c.cc.Invoke(ctx, SongLibrary_UpdateSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) DeleteSong(ctx context.Context, in *DeleteSongRequest, opts ...grpc.CallOption) (*DeleteSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSongResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_DeleteSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) GetVerses(ctx context.Context, in *GetVersesRequest, opts ...grpc.CallOption) (*GetVersesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersesResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_GetVerses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SongLibraryServer is the server API for SongLibrary service.
+// All implementations must embed UnimplementedSongLibraryServer
+// for forward compatibility.
+//
+// SongLibrary gives backend services typed access to the song library.
+// Errors use standard status codes; validation failures carry
+// google.rpc.BadRequest details and every domain error carries
+// google.rpc.ErrorInfo with the same code as the REST problem responses.
+type SongLibraryServer interface {
+	// GetSong returns a song by ID or public ID.
+	GetSong(context.Context, *GetSongRequest) (*Song, error)
+	// ListSongs streams every song matching the filter. The total number
+	// of matching songs is sent in the "x-total-count" header.
+	ListSongs(*ListSongsRequest, grpc.ServerStreamingServer[Song]) error
+	// AddSong adds a song; release date, text and link are fetched
+	// from the music info providers.
+	AddSong(context.Context, *AddSongRequest) (*Song, error)
+	// UpdateSong replaces the song data.
+	UpdateSong(context.Context, *UpdateSongRequest) (*Song, error)
+	// DeleteSong deletes a song by ID.
+	DeleteSong(context.Context, *DeleteSongRequest) (*DeleteSongResponse, error)
+	// GetVerses returns a page of song verses.
+	GetVerses(context.Context, *GetVersesRequest) (*GetVersesResponse, error)
+	mustEmbedUnimplementedSongLibraryServer()
+}
+
+// UnimplementedSongLibraryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSongLibraryServer struct{}
+
+func (UnimplementedSongLibraryServer) GetSong(context.Context, *GetSongRequest) (*Song, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSong not implemented")
+}
+func (UnimplementedSongLibraryServer) ListSongs(*ListSongsRequest, grpc.ServerStreamingServer[Song]) error {
+	return status.Error(codes.Unimplemented, "method ListSongs not implemented")
+}
+func (UnimplementedSongLibraryServer) AddSong(context.Context, *AddSongRequest) (*Song, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddSong not implemented")
+}
+func (UnimplementedSongLibraryServer) UpdateSong(context.Context, *UpdateSongRequest) (*Song, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSong not implemented")
+}
+func (UnimplementedSongLibraryServer) DeleteSong(context.Context, *DeleteSongRequest) (*DeleteSongResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSong not implemented")
+}
+func (UnimplementedSongLibraryServer) GetVerses(context.Context, *GetVersesRequest) (*GetVersesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVerses not implemented")
+}
+func (UnimplementedSongLibraryServer) mustEmbedUnimplementedSongLibraryServer() {}
+func (UnimplementedSongLibraryServer) testEmbeddedByValue()                     {}
+
+// UnsafeSongLibraryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SongLibraryServer will
+// result in compilation errors.
+type UnsafeSongLibraryServer interface {
+	mustEmbedUnimplementedSongLibraryServer()
+}
+
+func RegisterSongLibraryServer(s grpc.ServiceRegistrar, srv SongLibraryServer) {
+	// If the following call panics, it indicates UnimplementedSongLibraryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SongLibrary_ServiceDesc, srv)
+}
+
+func _SongLibrary_GetSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).GetSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_GetSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).GetSong(ctx, req.(*GetSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_ListSongs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSongsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SongLibraryServer).ListSongs(m, &grpc.GenericServerStream[ListSongsRequest, Song]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SongLibrary_ListSongsServer = grpc.ServerStreamingServer[Song]
+
+func _SongLibrary_AddSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).AddSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_AddSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).AddSong(ctx, req.(*AddSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_UpdateSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).UpdateSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_UpdateSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).UpdateSong(ctx, req.(*UpdateSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_DeleteSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).DeleteSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_DeleteSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).DeleteSong(ctx, req.(*DeleteSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_GetVerses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).GetVerses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_GetVerses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).GetVerses(ctx, req.(*GetVersesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SongLibrary_ServiceDesc is the grpc.ServiceDesc for SongLibrary service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SongLibrary_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "songlibrary.v1.SongLibrary",
+	HandlerType: (*SongLibraryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSong",
+			Handler:    _SongLibrary_GetSong_Handler,
+		},
+		{
+			MethodName: "AddSong",
+			Handler:    _SongLibrary_AddSong_Handler,
+		},
+		{
+			MethodName: "UpdateSong",
+			Handler:    _SongLibrary_UpdateSong_Handler,
+		},
+		{
+			MethodName: "DeleteSong",
+			Handler:    _SongLibrary_DeleteSong_Handler,
+		},
+		{
+			MethodName: "GetVerses",
+			Handler:    _SongLibrary_GetVerses_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSongs",
+			Handler:       _SongLibrary_ListSongs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "songlibrary/v1/songlibrary.proto",
+}
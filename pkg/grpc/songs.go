@@ -0,0 +1,256 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/entity"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/DusmatzodaQurbonli/song-library/internal/validation"
+	pb "github.com/DusmatzodaQurbonli/song-library/pkg/grpc/songlibraryv1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// listBatchSize — число песен, читаемых из базы за один запрос при потоковой выдаче
+	listBatchSize = 100
+	// totalCountHeader передаёт в заголовках потока число подходящих песен
+	totalCountHeader = "x-total-count"
+
+	defaultVersePageSize = 10
+)
+
+var orderFields = map[pb.SongOrderField]string{
+	pb.SongOrderField_SONG_ORDER_FIELD_ID:           "id",
+	pb.SongOrderField_SONG_ORDER_FIELD_GROUP:        "group",
+	pb.SongOrderField_SONG_ORDER_FIELD_TITLE:        "title",
+	pb.SongOrderField_SONG_ORDER_FIELD_RELEASE_DATE: "release_date",
+	pb.SongOrderField_SONG_ORDER_FIELD_CREATED_AT:   "created_at",
+	pb.SongOrderField_SONG_ORDER_FIELD_UPDATED_AT:   "updated_at",
+}
+
+// songLibraryServer реализует SongLibrary поверх SongService
+type songLibraryServer struct {
+	pb.UnimplementedSongLibraryServer
+	songs *service.SongService
+}
+
+// GetSong возвращает песню по ID
+func (s *songLibraryServer) GetSong(ctx context.Context, req *pb.GetSongRequest) (*pb.Song, error) {
+	id, err := s.songID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	song, err := s.songs.GetSong(ctx, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoSong(song), nil
+}
+
+// ListSongs передаёт подходящие песни потоком, читая их из базы пачками
+func (s *songLibraryServer) ListSongs(req *pb.ListSongsRequest, stream grpc.ServerStreamingServer[pb.Song]) error {
+	ctx := stream.Context()
+
+	filter := make(map[string]string)
+	if req.GetGroup() != "" {
+		filter["group"] = req.GetGroup()
+	}
+	if req.GetTitle() != "" {
+		filter["title"] = req.GetTitle()
+	}
+	order := service.SongOrder{Desc: req.GetDescending()}
+	if req.GetOrderBy() != pb.SongOrderField_SONG_ORDER_FIELD_UNSPECIFIED {
+		field, ok := orderFields[req.GetOrderBy()]
+		if !ok {
+			return invalidField("order_by", "must be a known SongOrderField")
+		}
+		order.Field = field
+	}
+	limit := int(req.GetLimit())
+
+	sent := 0
+	for page := 1; ; page++ {
+		songs, total, err := s.songs.GetSongs(ctx, filter, order, nil, page, listBatchSize)
+		if err != nil {
+			return statusError(err)
+		}
+		if page == 1 {
+			header := metadata.Pairs(totalCountHeader, strconv.FormatInt(total, 10))
+			if err := stream.SendHeader(header); err != nil {
+				return err
+			}
+		}
+
+		for i := range songs {
+			if limit > 0 && sent >= limit {
+				return nil
+			}
+			if err := stream.Send(toProtoSong(&songs[i])); err != nil {
+				return err
+			}
+			sent++
+		}
+		if len(songs) < listBatchSize {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// AddSong добавляет песню, запрашивая сведения у провайдеров
+func (s *songLibraryServer) AddSong(ctx context.Context, req *pb.AddSongRequest) (*pb.Song, error) {
+	input := validation.AddSongRequest{Group: req.GetGroup(), Title: req.GetTitle()}
+	if err := validation.Struct(input); err != nil {
+		return nil, statusError(err)
+	}
+
+	song, err := s.songs.AddSong(ctx, input.Song())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoSong(song), nil
+}
+
+// UpdateSong заменяет данные песни
+func (s *songLibraryServer) UpdateSong(ctx context.Context, req *pb.UpdateSongRequest) (*pb.Song, error) {
+	id, err := s.songID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var releaseDate time.Time
+	if req.GetReleaseDate() != nil {
+		releaseDate = req.GetReleaseDate().AsTime()
+	}
+	input := validation.UpdateSongRequest{
+		Group:       req.GetGroup(),
+		Title:       req.GetTitle(),
+		ReleaseDate: releaseDate,
+		Text:        req.GetText(),
+		Link:        req.GetLink(),
+	}
+	if err := validation.Struct(input); err != nil {
+		return nil, statusError(err)
+	}
+
+	song := input.Song(id)
+	if err := s.songs.UpdateSong(ctx, song); err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoSong(song), nil
+}
+
+// DeleteSong удаляет песню по ID
+func (s *songLibraryServer) DeleteSong(ctx context.Context, req *pb.DeleteSongRequest) (*pb.DeleteSongResponse, error) {
+	id, err := s.songID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.songs.DeleteSong(ctx, id); err != nil {
+		return nil, statusError(err)
+	}
+	return &pb.DeleteSongResponse{}, nil
+}
+
+// GetVerses возвращает страницу куплетов песни
+func (s *songLibraryServer) GetVerses(ctx context.Context, req *pb.GetVersesRequest) (*pb.GetVersesResponse, error) {
+	id, err := s.songID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	query := validation.PageQuery{Page: int(req.GetPage()), Size: int(req.GetSize())}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Size == 0 {
+		query.Size = defaultVersePageSize
+	}
+	if err := validation.Struct(query); err != nil {
+		return nil, statusError(err)
+	}
+
+	_, verses, total, err := s.songs.GetSongText(ctx, id, query.Page, query.Size)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	totalPages := (total + query.Size - 1) / query.Size
+	if last := max(totalPages, 1); query.Page > last {
+		return nil, invalidField("page", fmt.Sprintf("must be at most %d", last))
+	}
+
+	return &pb.GetVersesResponse{
+		Verses: verses,
+		Pagination: &pb.Pagination{
+			Page:       uint32(query.Page),
+			Size:       uint32(query.Size),
+			Total:      uint64(total),
+			TotalPages: uint32(totalPages),
+		},
+	}, nil
+}
+
+// songRequest — запрос, ссылающийся на песню по ID или публичному ID
+type songRequest interface {
+	GetId() uint64
+	GetPublicId() string
+}
+
+// songID возвращает внутренний ID песни, указанной в запросе
+func (s *songLibraryServer) songID(ctx context.Context, req songRequest) (uint, error) {
+	if publicID := req.GetPublicId(); publicID != "" {
+		if _, err := uuid.Parse(publicID); err != nil {
+			return 0, invalidField("public_id", "must be a UUID")
+		}
+		id, err := s.songs.ResolveSongID(ctx, publicID)
+		if err != nil {
+			return 0, statusError(err)
+		}
+		return id, nil
+	}
+	if req.GetId() == 0 {
+		return 0, invalidField("id", "must be a positive integer")
+	}
+	return uint(req.GetId()), nil
+}
+
+func toProtoSong(song *entity.Song) *pb.Song {
+	return &pb.Song{
+		Id:          uint64(song.ID),
+		PublicId:    song.PublicID.String(),
+		Slug:        song.Slug,
+		Group:       song.Group,
+		Title:       song.Title,
+		ReleaseDate: timestamp(song.ReleaseDate),
+		Text:        song.Text,
+		Link:        song.Link,
+		SyncedAt:    optionalTimestamp(song.SyncedAt),
+		CreatedAt:   timestamp(song.CreatedAt),
+		UpdatedAt:   timestamp(song.UpdatedAt),
+	}
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
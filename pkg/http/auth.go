@@ -0,0 +1,53 @@
+package http
+
+import (
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// authMiddleware определяет субъект запроса по API-ключу или JWT из
+// Authorization: Bearer либо по ключу из X-API-Key. Недействительные
+// учётные данные отклоняются на любом маршруте и расходуют бюджет адреса
+// клиента, чтобы перебор ключей упирался в ограничение частоты. Запрос без
+// учётных данных продолжается как анонимный.
+func (s *Server) authMiddleware(c *gin.Context) {
+	if s.config.Auth.Enabled {
+		c.Writer.Header().Add("Vary", "Authorization, "+apiKeyHeader)
+	}
+
+	credential := auth.KeyFromHeaders(c.GetHeader("Authorization"), c.GetHeader(apiKeyHeader))
+	principal, err := s.authenticator.Authenticate(c.Request.Context(), credential)
+	if err != nil {
+		if limitErr := s.takeRateLimit(c, service.BudgetFailedAuth); limitErr != nil {
+			err = limitErr
+		}
+		s.abortUnauthorized(c, err)
+		return
+	}
+
+	c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+	c.Next()
+}
+
+// requirePermission пропускает запрос, только если роль субъекта даёт право perm
+func (s *Server) requirePermission(perm auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := auth.Require(c.Request.Context(), perm); err != nil {
+			s.abortUnauthorized(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+func (s *Server) abortUnauthorized(c *gin.Context, err error) {
+	if apperror.IsKind(err, apperror.KindUnauthorized) {
+		c.Header("WWW-Authenticate", `Bearer realm="song-library"`)
+	}
+	c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func TestAuthMiddlewareLimitsFailedAuth(t *testing.T) {
+	jwks := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(jwks, []byte(`{"keys":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{}
+	cfg.Auth = config.Auth{Enabled: true, JWT: config.JWT{Enabled: true, JWKSFile: jwks}}
+	cfg.RateLimit = config.RateLimit{
+		Enabled: true,
+		Read:    config.RateLimitBudget{Requests: 100},
+		Write:   config.RateLimitBudget{Requests: 2},
+	}
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	authenticator, err := service.NewAuthService(nil, cfg, log)
+	if err != nil {
+		t.Fatalf("NewAuthService() error = %v", err)
+	}
+	rateLimits, err := service.NewRateLimitService(cfg, nil, log)
+	if err != nil {
+		t.Fatalf("NewRateLimitService() error = %v", err)
+	}
+
+	s := newTestServer()
+	s.config = cfg
+	s.authenticator = authenticator
+	s.rateLimits = rateLimits
+	s.router.Use(s.authMiddleware)
+	s.router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// неудачные попытки списываются с бюджета записи адреса клиента
+	for i, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer not.a.token")
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("attempt %d: status = %d, want %d", i+1, w.Code, want)
+		}
+	}
+}
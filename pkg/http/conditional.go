@@ -0,0 +1,135 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bufferedWriter задерживает тело ответа, чтобы вычислить ETag
+// до отправки заголовков
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.status != 0
+}
+
+// conditionalMiddleware добавляет к успешным GET-ответам строгий ETag
+// по содержимому и Cache-Control и отвечает 304 на If-None-Match
+// и If-Modified-Since
+func (s *Server) conditionalMiddleware(c *gin.Context) {
+	if c.Request.Method != http.MethodGet {
+		c.Next()
+		return
+	}
+
+	original := c.Writer
+	buffered := &bufferedWriter{ResponseWriter: original}
+	c.Writer = buffered
+	c.Next()
+	c.Writer = original
+
+	if !buffered.Written() {
+		return
+	}
+
+	if buffered.status == http.StatusOK {
+		sum := sha256.Sum256(buffered.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		original.Header().Set("ETag", etag)
+		if cacheControl := s.cacheControl(c.FullPath()); cacheControl != "" {
+			original.Header().Set("Cache-Control", cacheControl)
+		}
+
+		if notModified(c.Request, etag, original.Header().Get("Last-Modified")) {
+			original.Header().Del("Content-Type")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+	}
+
+	original.WriteHeader(buffered.status)
+	_, _ = original.Write(buffered.body.Bytes())
+}
+
+// cacheControl возвращает Cache-Control маршрута; маршруты без префикса
+// версии наследуют настройку соответствующего маршрута legacyVersion
+func (s *Server) cacheControl(path string) string {
+	routes := s.config.API.Cache.Routes
+	key := deprecationKey(http.MethodGet, path)
+	if value, ok := routes[key]; ok {
+		return value
+	}
+	if !strings.HasPrefix(path, "/api/") {
+		if value, ok := routes[deprecationKey(http.MethodGet, "/api/"+legacyVersion+path)]; ok {
+			return value
+		}
+	}
+	return s.config.API.Cache.Default
+}
+
+// notModified проверяет предусловия по RFC 9110: If-Modified-Since
+// учитывается только в отсутствие If-None-Match
+func notModified(r *http.Request, etag, lastModified string) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+
+	// statusClientClosedRequest — клиент закрыл соединение, не дождавшись
+	// ответа (код из nginx)
+	statusClientClosedRequest = 499
+
+	// maxRequestIDLength ограничивает идентификатор запроса от клиента
+	maxRequestIDLength = 128
+)
+
+// requestIDMiddleware присваивает запросу идентификатор. Идентификатор
+// клиента принимается, только если он не длиннее maxRequestIDLength и
+// состоит из латинских букв, цифр и символов "-_.:", иначе создаётся новый:
+// значение попадает в журнал и заголовки ответа.
+func (s *Server) requestIDMiddleware(c *gin.Context) {
+	requestID := c.GetHeader(requestIDHeader)
+	if !validRequestID(requestID) {
+		requestID = uuid.NewString()
+	}
+
+	c.Set(requestIDKey, requestID)
+	c.Header(requestIDHeader, requestID)
+	c.Next()
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// recoveryMiddleware перехватывает панику обработчика и отвечает
+// application/problem+json с кодом 500, не раскрывая её причину
+func (s *Server) recoveryMiddleware(c *gin.Context) {
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+		if rec == http.ErrAbortHandler {
+			// обработчик намеренно оборвал ответ
+			panic(rec)
+		}
+
+		s.log.WithFields(logrus.Fields{
+			"panic":      rec,
+			requestIDKey: c.GetString(requestIDKey),
+			"path":       c.Request.URL.Path,
+			"stack":      string(debug.Stack()),
+		}).Error("Recovered from panic")
+
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		problem := apperror.NewProblem(fmt.Errorf("panic: %v", rec), c.Request.URL.Path, c.GetString(requestIDKey))
+		body, _ := json.Marshal(problem)
+		c.Data(problem.Status, apperror.ContentType, body)
+		c.Abort()
+	}()
+	c.Next()
+}
+
+// errorMiddleware отображает последнюю ошибку обработчика в application/problem+json
+func (s *Server) errorMiddleware(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	err := c.Errors.Last().Err
+	if errors.Is(err, context.Canceled) && c.Request.Context().Err() != nil {
+		c.Status(statusClientClosedRequest)
+		return
+	}
+	problem := apperror.NewProblem(err, c.Request.URL.Path, c.GetString(requestIDKey))
+
+	if appErr, ok := apperror.As(err); ok && appErr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+	}
+
+	body, _ := json.Marshal(problem)
+	c.Data(problem.Status, apperror.ContentType, body)
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestServer() *Server {
+	gin.SetMode(gin.TestMode)
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	s := &Server{router: gin.New(), log: log}
+	s.router.Use(s.requestIDMiddleware, s.recoveryMiddleware, s.errorMiddleware)
+	return s
+}
+
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"3f2c1a9e-8b7d-4c6e-9f0a-1b2c3d4e5f60", true},
+		{"req_42.retry:1", true},
+		{strings.Repeat("a", maxRequestIDLength), true},
+		{strings.Repeat("a", maxRequestIDLength+1), false},
+		{"", false},
+		{"id with spaces", false},
+		{"id\r\nX-Injected: 1", false},
+		{"<script>", false},
+		{"идентификатор", false},
+	}
+	for _, tt := range tests {
+		if got := validRequestID(tt.id); got != tt.want {
+			t.Errorf("validRequestID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	s := newTestServer()
+	s.router.GET("/", func(c *gin.Context) { c.String(http.StatusOK, c.GetString(requestIDKey)) })
+
+	tests := []struct {
+		header string
+		kept   bool
+	}{
+		{"client-id-1", true},
+		{"", false},
+		{strings.Repeat("x", 1000), false},
+		{"bad id", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			r.Header.Set(requestIDHeader, tt.header)
+		}
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, r)
+
+		got := w.Header().Get(requestIDHeader)
+		if got != w.Body.String() {
+			t.Errorf("header %q: response header %q differs from context %q", tt.header, got, w.Body.String())
+		}
+		if (got == tt.header) != tt.kept || !validRequestID(got) {
+			t.Errorf("header %q: request ID = %q, kept = %v", tt.header, got, tt.kept)
+		}
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	s := newTestServer()
+	s.router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	r := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	r.Header.Set(requestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != apperror.ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, apperror.ContentType)
+	}
+	var problem apperror.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid problem %q: %v", w.Body.String(), err)
+	}
+	if problem.Code != "internal_error" || problem.RequestID != "req-1" || problem.Instance != "/panic" {
+		t.Errorf("problem = %+v", problem)
+	}
+	if strings.Contains(w.Body.String(), "boom") {
+		t.Errorf("problem discloses the panic value: %s", w.Body.String())
+	}
+}
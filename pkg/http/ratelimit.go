@@ -0,0 +1,41 @@
+package http
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimit списывает запрос из бюджета клиента и сообщает остаток
+// в заголовках RateLimit-* (draft-ietf-httpapi-ratelimit-headers).
+// Отклонённый запрос получает 429 с Retry-After.
+func (s *Server) rateLimit(budget service.RateLimitBudget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.takeRateLimit(c, budget); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// takeRateLimit списывает запрос из бюджета и выставляет заголовки RateLimit-*
+func (s *Server) takeRateLimit(c *gin.Context, budget service.RateLimitBudget) error {
+	ctx := c.Request.Context()
+	result, err := s.rateLimits.Allow(ctx, budget, service.RateLimitClient(ctx, c.ClientIP()))
+	if result != nil {
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit.Burst))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+		c.Header("RateLimit-Policy", result.Limit.Policy())
+	}
+	return err
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
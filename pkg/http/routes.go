@@ -0,0 +1,150 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
+	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/handler"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// legacyVersion — версия, чьи маршруты также доступны без префикса /api
+const legacyVersion = "v1"
+
+// route описывает маршрут API и право, необходимое для вызова
+type route struct {
+	method  string
+	path    string
+	handler gin.HandlerFunc
+	perm    auth.Permission
+}
+
+// apiVersion описывает набор маршрутов одной версии API
+type apiVersion struct {
+	name   string
+	routes []route
+}
+
+// apiVersions перечисляет версии API. Новая версия (например, v2) добавляется
+// сюда со своим набором маршрутов и обслуживается параллельно с прежними.
+func apiVersions(h handlers) []apiVersion {
+	return []apiVersion{
+		{name: "v1", routes: routesV1(h)},
+	}
+}
+
+// handlers собирает обработчики REST API
+type handlers struct {
+	songs   *handler.SongHandler
+	resync  *handler.ResyncHandler
+	lyrics  *handler.LyricsHandler
+	apiKeys *handler.APIKeyHandler
+}
+
+func routesV1(h handlers) []route {
+	songs, resync, lyrics, apiKeys := h.songs, h.resync, h.lyrics, h.apiKeys
+	return []route{
+		{http.MethodPost, "/songs/", songs.AddSong, auth.PermCreate},
+		{http.MethodGet, "/songs/:id", songs.GetSongText, auth.PermRead},
+		{http.MethodGet, "/songs/", songs.GetSongs, auth.PermRead},
+		{http.MethodPost, "/songs/batch", songs.GetSongsBatch, auth.PermRead},
+		{http.MethodGet, "/songs/by-slug/:artist/:title", songs.GetSongBySlug, auth.PermRead},
+		{http.MethodPut, "/songs/:id", songs.UpdateSong, auth.PermUpdate},
+		{http.MethodDelete, "/songs/:id", songs.DeleteSong, auth.PermDelete},
+		{http.MethodPut, "/songs/:id/locks", songs.SetFieldLocks, auth.PermUpdate},
+
+		{http.MethodGet, "/songs/:id/lyrics/stanzas", lyrics.GetStanzas, auth.PermRead},
+		{http.MethodGet, "/songs/:id/lyrics/timing", lyrics.GetTiming, auth.PermRead},
+		{http.MethodPut, "/songs/:id/lyrics/timing", lyrics.SetTiming, auth.PermUpdate},
+		{http.MethodDelete, "/songs/:id/lyrics/timing", lyrics.DeleteTiming, auth.PermUpdate},
+		{http.MethodGet, "/songs/:id/lyrics/at", lyrics.GetLineAt, auth.PermRead},
+
+		{http.MethodPost, "/songs/resync", resync.Resync, auth.PermImport},
+		{http.MethodGet, "/songs/changes", resync.GetChanges, auth.PermRead},
+		{http.MethodPost, "/songs/changes/:id/apply", resync.ApplyChange, auth.PermImport},
+		{http.MethodPost, "/songs/changes/:id/reject", resync.RejectChange, auth.PermImport},
+
+		{http.MethodGet, "/admin/keys", apiKeys.GetKeys, auth.PermAdmin},
+		{http.MethodPost, "/admin/keys", apiKeys.CreateKey, auth.PermAdmin},
+		{http.MethodGet, "/admin/keys/:id", apiKeys.GetKey, auth.PermAdmin},
+		{http.MethodPost, "/admin/keys/:id/rotate", apiKeys.RotateKey, auth.PermAdmin},
+		{http.MethodDelete, "/admin/keys/:id", apiKeys.RevokeKey, auth.PermAdmin},
+	}
+}
+
+// registerRoutes подключает маршруты, ограничивая частоту запросов
+// и проверяя право субъекта перед обработчиком
+func (s *Server) registerRoutes(group *gin.RouterGroup, routes []route) {
+	for _, r := range routes {
+		group.Handle(r.method, r.path, s.rateLimit(service.BudgetFor(r.perm)), s.requirePermission(r.perm), r.handler)
+	}
+}
+
+// deprecation содержит заголовки устаревшего маршрута.
+// successorPrefix строит ссылку на замену из пути запроса.
+type deprecation struct {
+	since           time.Time
+	sunset          time.Time
+	link            string
+	successorPrefix string
+}
+
+func deprecationKey(method, path string) string {
+	return method + " " + path
+}
+
+func newDeprecation(since, sunset, link string) (deprecation, error) {
+	d := deprecation{link: link}
+	var err error
+	if since != "" {
+		if d.since, err = time.Parse(time.RFC3339, since); err != nil {
+			return d, fmt.Errorf("invalid deprecation date %q: %w", since, err)
+		}
+	}
+	if sunset != "" {
+		if d.sunset, err = time.Parse(time.RFC3339, sunset); err != nil {
+			return d, fmt.Errorf("invalid sunset date %q: %w", sunset, err)
+		}
+	}
+	return d, nil
+}
+
+// loadDeprecations собирает устаревшие маршруты из конфигурации
+func loadDeprecations(cfg config.API) (map[string]deprecation, error) {
+	deprecations := make(map[string]deprecation, len(cfg.Deprecations))
+	for _, rd := range cfg.Deprecations {
+		d, err := newDeprecation(rd.Since, rd.Sunset, rd.Link)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", rd.Method, rd.Path, err)
+		}
+		deprecations[deprecationKey(rd.Method, rd.Path)] = d
+	}
+	return deprecations, nil
+}
+
+// deprecationMiddleware выставляет заголовки Deprecation (RFC 9745),
+// Sunset (RFC 8594) и ссылку на замену для устаревших маршрутов
+func (s *Server) deprecationMiddleware(c *gin.Context) {
+	d, ok := s.deprecations[deprecationKey(c.Request.Method, c.FullPath())]
+	if ok {
+		if !d.since.IsZero() {
+			c.Header("Deprecation", "@"+strconv.FormatInt(d.since.Unix(), 10))
+		}
+		if !d.sunset.IsZero() {
+			c.Header("Sunset", d.sunset.UTC().Format(http.TimeFormat))
+		}
+		link := d.link
+		if d.successorPrefix != "" {
+			link = d.successorPrefix + c.Request.URL.RequestURI()
+		}
+		if link != "" {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", link))
+		}
+	}
+	c.Next()
+}
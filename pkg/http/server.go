@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
@@ -10,8 +11,11 @@ import (
 	"time"
 
 	_ "github.com/DusmatzodaQurbonli/song-library/docs"
+	"github.com/DusmatzodaQurbonli/song-library/internal/auth"
 	"github.com/DusmatzodaQurbonli/song-library/internal/config"
+	"github.com/DusmatzodaQurbonli/song-library/internal/graph"
 	"github.com/DusmatzodaQurbonli/song-library/internal/handler"
+	"github.com/DusmatzodaQurbonli/song-library/internal/service"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
@@ -19,26 +23,68 @@ import (
 )
 
 type Server struct {
-	router *gin.Engine
-	log    *logrus.Logger
-	config *config.Config
+	router        *gin.Engine
+	log           *logrus.Logger
+	config        *config.Config
+	authenticator *service.AuthService
+	rateLimits    *service.RateLimitService
+	deprecations  map[string]deprecation
 }
 
-func NewServer(handler *handler.SongHandler, log *logrus.Logger, config *config.Config) *Server {
-	router := gin.Default()
+func NewServer(
+	handler *handler.SongHandler,
+	resyncHandler *handler.ResyncHandler,
+	lyricsHandler *handler.LyricsHandler,
+	graphHandler *graph.Handler,
+	musicInfoHandler *handler.MusicInfoHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	authenticator *service.AuthService,
+	rateLimits *service.RateLimitService,
+	log *logrus.Logger,
+	config *config.Config,
+) (*Server, error) {
+	router := gin.New()
+	router.Use(gin.Logger())
+	// адрес клиента из X-Forwarded-For ключует лимиты запросов, поэтому
+	// заголовок принимается только от настроенных прокси
+	if err := router.SetTrustedProxies(config.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	deprecations, err := loadDeprecations(config.API)
+	if err != nil {
+		return nil, err
+	}
 
 	server := &Server{
-		router: router,
-		log:    log,
-		config: config,
+		router:        router,
+		log:           log,
+		config:        config,
+		authenticator: authenticator,
+		rateLimits:    rateLimits,
+		deprecations:  deprecations,
 	}
 
-	router.Use(gin.Recovery())
+	router.Use(server.requestIDMiddleware)
 	router.Use(server.loggingMiddleware)
+	router.Use(server.recoveryMiddleware)
+	router.Use(server.errorMiddleware)
+	router.Use(server.deprecationMiddleware)
+	router.Use(server.conditionalMiddleware)
+	router.Use(server.authMiddleware)
+
+	h := handlers{songs: handler, resync: resyncHandler, lyrics: lyricsHandler, apiKeys: apiKeyHandler}
+	if err := server.setupRoutes(h); err != nil {
+		return nil, err
+	}
+	server.setupGraphQL(graphHandler)
+	if config.API.ServeMusicInfo {
+		// путь /info задан спецификацией Music Info API и не версионируется
+		router.GET("/info", server.rateLimit(service.BudgetRead), server.requirePermission(auth.PermRead),
+			gin.WrapH(musicInfoHandler.Router()))
+	}
 
-	server.setupRoutes(handler)
-
-	return server
+	return server, nil
 }
 
 func (s *Server) loggingMiddleware(c *gin.Context) {
@@ -48,20 +94,66 @@ func (s *Server) loggingMiddleware(c *gin.Context) {
 	latency := time.Since(start)
 	status := c.Writer.Status()
 
-	s.log.Infof("[%d] %s %s | %s | %v",
+	entry := s.log.WithField(requestIDKey, c.GetString(requestIDKey))
+	if p, ok := auth.FromContext(c.Request.Context()); ok && !p.Anonymous {
+		if p.Subject != "" {
+			entry = entry.WithField("subject", p.Subject)
+		} else {
+			entry = entry.WithField("api_key_id", p.KeyID)
+		}
+	}
+	entry.Infof("[%d] %s %s | %s | %v",
 		status, c.Request.Method, c.Request.URL.Path, c.ClientIP(), latency)
 }
 
-func (s *Server) setupRoutes(handler *handler.SongHandler) {
+func (s *Server) setupRoutes(h handlers) error {
 	s.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	api := s.router.Group("/songs")
-	{
-		api.POST("/", handler.AddSong)
-		api.GET("/:id", handler.GetSongText)
-		api.GET("/", handler.GetSongs)
-		api.PUT("/:id", handler.UpdateSong)
-		api.DELETE("/:id", handler.DeleteSong)
+	for _, version := range apiVersions(h) {
+		prefix := "/api/" + version.name
+		s.registerRoutes(s.router.Group(prefix), version.routes)
+
+		if version.name != legacyVersion || s.config.API.DisableLegacyRoutes {
+			continue
+		}
+
+		// маршруты без префикса остаются псевдонимами и помечаются устаревшими
+		for _, r := range version.routes {
+			key := deprecationKey(r.method, r.path)
+			if _, ok := s.deprecations[key]; ok {
+				continue
+			}
+			d, err := newDeprecation(s.config.API.LegacySince, s.config.API.LegacySunset, "")
+			if err != nil {
+				return fmt.Errorf("legacy routes: %w", err)
+			}
+			d.successorPrefix = prefix
+			s.deprecations[key] = d
+		}
+		s.registerRoutes(&s.router.RouterGroup, version.routes)
+	}
+
+	return nil
+}
+
+// setupGraphQL подключает эндпоинт /graphql вне версий REST API:
+// схема GraphQL развивается без смены префикса
+func (s *Server) setupGraphQL(graphHandler *graph.Handler) {
+	if !s.config.GraphQL.Enabled {
+		return
+	}
+	// права на мутации проверяет SongService; бюджет запросов обработчик
+	// выбирает сам после разбора операции
+	serveGraphQL := func(c *gin.Context) {
+		ctx := graph.WithRateLimit(c.Request.Context(), func(budget service.RateLimitBudget) error {
+			return s.takeRateLimit(c, budget)
+		})
+		graphHandler.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+	}
+	s.router.GET("/graphql", s.requirePermission(auth.PermRead), serveGraphQL)
+	s.router.POST("/graphql", s.requirePermission(auth.PermRead), serveGraphQL)
+	if s.config.GraphQL.Playground {
+		s.router.GET("/graphql/playground", gin.WrapH(graph.Playground("/graphql")))
 	}
 }
 